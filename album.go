@@ -65,6 +65,7 @@ type trackResource struct {
 	Album         AlbumResource    `json:"album"`
 	TrackNumber   int              `json:"trackNumber"`
 	VolumeNumber  int              `json:"volumeNumber"`
+	Duration      int              `json:"duration"`
 	MediaMetaData MediaMetaData    `json:"mediaMetadata"`
 	Properties    AlbumProperties  `json:"properties"`
 	TidalURL      string           `json:"tidalUrl"`
@@ -158,36 +159,52 @@ func (c *Client) GetAlbumTracks(ctx context.Context, id string) ([]Track, error)
 }
 
 // GetAlbumByBarcodeID returns a list of albums that match a barcode ID.
+//
+// The barcode is normalized with ParseBarcode, so UPC-A and EAN-13 codes, with or without their leading zeros, find
+// the same albums. Barcodes with an invalid check digit return ErrInvalidBarcode without making a request.
 func (c *Client) GetAlbumByBarcodeID(ctx context.Context, barcodeID string) ([]Album, error) {
 	if barcodeID == "" {
 		return nil, ErrMissingRequiredParameters
 	}
 
+	barcode, err := ParseBarcode(barcodeID)
+	if err != nil {
+		return nil, err
+	}
+
 	type barcodeParams struct {
 		barcodeId string // nolint:revive // This variable is directly referenced in the query string.
 	}
 
-	params := barcodeParams{
-		barcodeId: barcodeID,
-	}
+	var results albumResults
 
-	response, err := c.request(ctx, http.MethodGet, "/albums/byBarcodeId", params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to the albums endpoint: %w", err)
-	}
+	// Albums are stored under the form of the barcode the label registered, so try each form until one matches.
+	for _, form := range barcode.lookupForms() {
+		response, err := c.request(ctx, http.MethodGet, "/albums/byBarcodeId", barcodeParams{barcodeId: form})
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to the albums endpoint: %w", err)
+		}
 
-	var results albumResults
+		err = json.Unmarshal(response, &results)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the albums response body: %w", err)
+		}
 
-	err = json.Unmarshal(response, &results)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal the albums response body: %w", err)
+		if len(results.Data) > 0 {
+			break
+		}
 	}
 
 	return results.Data, nil
 }
 
-// GetMultipleAlbums returns a list of albums filtered by their IDs.
+// GetMultipleAlbums returns a list of albums filtered by their IDs. Any number of IDs can be given; they are fetched
+// in batches the endpoint accepts.
 func (c *Client) GetMultipleAlbums(ctx context.Context, ids []string) ([]Album, error) {
+	return fetchInBatches(ctx, ids, c.multipleAlbums)
+}
+
+func (c *Client) multipleAlbums(ctx context.Context, ids []string) ([]Album, error) {
 	params := idListParams{
 		ids: strings.Join(ids, ","),
 	}
@@ -220,16 +237,22 @@ type similarAlbumResults struct {
 
 // GetSimilarAlbums returns a slice of album IDs that can be used as a parameter in the GetMultipleAlbums function.
 func (c *Client) GetSimilarAlbums(ctx context.Context, id string, params PaginationParams) ([]string, error) {
+	albumIDs, _, err := c.similarAlbumIDs(ctx, id, params)
+
+	return albumIDs, err
+}
+
+func (c *Client) similarAlbumIDs(ctx context.Context, id string, params PaginationParams) ([]string, int, error) {
 	response, err := c.request(ctx, http.MethodGet, concat("/albums/", id, "/similar"), params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to the similar albums endpoint: %w", err)
+		return nil, 0, fmt.Errorf("failed to connect to the similar albums endpoint: %w", err)
 	}
 
 	var results similarAlbumResults
 
 	err = json.Unmarshal(response, &results)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal the similar albums response body: %w", err)
+		return nil, 0, fmt.Errorf("failed to unmarshal the similar albums response body: %w", err)
 	}
 
 	var albumIDs []string
@@ -237,7 +260,41 @@ func (c *Client) GetSimilarAlbums(ctx context.Context, id string, params Paginat
 		albumIDs = append(albumIDs, albumID.Resource.ID)
 	}
 
-	return albumIDs, nil
+	return albumIDs, results.MetaData.Total, nil
+}
+
+// SimilarAlbums contains the hydrated results of a similar albums lookup.
+type SimilarAlbums struct {
+	// Albums are ordered by similarity rank, most similar first.
+	Albums []Album
+
+	// Unresolved lists the IDs of similar albums that were not returned by the multiple albums endpoint, or whose
+	// request failed, in similarity rank order.
+	Unresolved []string
+}
+
+// GetSimilarAlbumsResolved returns every album similar to an album ID as full Album values.
+//
+// The similar albums endpoint only returns IDs so this pages through all of them and fetches the albums in batches
+// using the multiple albums endpoint. Albums are returned in similarity rank order and any IDs that could not be
+// fetched, including every ID of a batch that failed, are reported in Unresolved rather than failing the whole call.
+func (c *Client) GetSimilarAlbumsResolved(ctx context.Context, id string) (*SimilarAlbums, error) {
+	if id == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	listIDs := func(ctx context.Context, params PaginationParams) ([]string, int, error) {
+		return c.similarAlbumIDs(ctx, id, params)
+	}
+
+	albumID := func(album Album) string { return album.ID }
+
+	albums, unresolved, err := resolveSimilar(ctx, listIDs, c.multipleAlbums, albumID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimilarAlbums{Albums: albums, Unresolved: unresolved}, nil
 }
 
 // GetAlbumsByArtist returns a list of albums that match an artist ID.
@@ -259,9 +316,17 @@ func (c *Client) GetSingleTrack(ctx context.Context, id string) (*Track, error)
 
 // GetTracksByISRC returns a list of tracks that match an ISRC.
 //
+// The ISRC is normalized with ParseISRC, so hyphenated and lower case codes are accepted. Malformed codes return
+// ErrInvalidISRC without making a request.
+//
 // ISRC lookup can be found here. This is a useful tool for finding ISRCs for testing purposes:
 // https://isrcsearch.ifpi.org/
 func (c *Client) GetTracksByISRC(ctx context.Context, isrc string, params PaginationParams) ([]Track, error) {
+	code, err := ParseISRC(isrc)
+	if err != nil {
+		return nil, err
+	}
+
 	type isrcParams struct {
 		isrc   string
 		Limit  int
@@ -269,7 +334,7 @@ func (c *Client) GetTracksByISRC(ctx context.Context, isrc string, params Pagina
 	}
 
 	response, err := c.request(ctx, http.MethodGet, "/tracks/byIsrc", isrcParams{
-		isrc:   isrc,
+		isrc:   code.String(),
 		Limit:  params.Limit,
 		Offset: params.Offset,
 	})
@@ -287,8 +352,13 @@ func (c *Client) GetTracksByISRC(ctx context.Context, isrc string, params Pagina
 	return result.Data, nil
 }
 
-// GetMultipleTracks returns a list of tracks filtered by their IDs.
+// GetMultipleTracks returns a list of tracks filtered by their IDs. Any number of IDs can be given; they are fetched
+// in batches the endpoint accepts.
 func (c *Client) GetMultipleTracks(ctx context.Context, ids []string) ([]Track, error) {
+	return fetchInBatches(ctx, ids, c.multipleTracks)
+}
+
+func (c *Client) multipleTracks(ctx context.Context, ids []string) ([]Track, error) {
 	params := idListParams{
 		ids: strings.Join(ids, ","),
 	}
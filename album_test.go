@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"reflect"
+	"strconv"
 	"testing"
 )
 
@@ -327,7 +328,7 @@ func TestGetTracksByISRC(t *testing.T) {
 			"Token Error",
 			args{
 				httpClient: &mockHTTPClient{FilePath: "testdata/401-token-error.json", StatusCode: http.StatusUnauthorized},
-				id:         "51584179",
+				id:         "GBAAP1500379",
 			},
 			expected{
 				count: 0,
@@ -338,6 +339,17 @@ func TestGetTracksByISRC(t *testing.T) {
 			"Bad Response",
 			args{
 				httpClient: &mockHTTPClient{FilePath: "testdata/invalid-json.json", StatusCode: http.StatusInternalServerError},
+				id:         "GBAAP1500379",
+			},
+			expected{
+				count: 0,
+			},
+			true,
+		},
+		{
+			"Malformed ISRC",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/tracks-by-isrc.json", StatusCode: http.StatusOK},
 				id:         "51584179",
 			},
 			expected{
@@ -345,11 +357,22 @@ func TestGetTracksByISRC(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"Hyphenated lower case ISRC",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/tracks-by-isrc.json", StatusCode: http.StatusOK},
+				id:         "gb-aap-15-00379",
+			},
+			expected{
+				count: 2,
+			},
+			false,
+		},
 		{
 			"Count of tracks by ISRC",
 			args{
 				httpClient: &mockHTTPClient{FilePath: "testdata/tracks-by-isrc.json", StatusCode: http.StatusOK},
-				id:         "51584179",
+				id:         "GBAAP1500379",
 			},
 			expected{
 				count: 2,
@@ -464,3 +487,134 @@ func TestGetMultipleTracks(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSimilarAlbumsResolved(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		httpClient HTTPClient
+		id         string
+	}
+
+	type expected struct {
+		AlbumIDs   []string
+		Unresolved []string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		wantErr  bool
+	}{
+		{
+			"Missing ID",
+			args{
+				httpClient: &mockRoutedHTTPClient{},
+				id:         "",
+			},
+			expected{},
+			true,
+		},
+		{
+			"Similar albums resolve in rank order",
+			args{
+				httpClient: &mockRoutedHTTPClient{Routes: map[string]string{
+					"/albums/51584178/similar": "testdata/similar-albums-partial.json",
+					"/albums/byIds":            "testdata/multiple-albums.json",
+				}},
+				id: "51584178",
+			},
+			expected{
+				AlbumIDs:   []string{"17927863", "51584178"},
+				Unresolved: []string{"1234"},
+			},
+			false,
+		},
+		{
+			"Multiple albums endpoint error leaves the batch unresolved",
+			args{
+				httpClient: &mockRoutedHTTPClient{Routes: map[string]string{
+					"/albums/51584178/similar": "testdata/similar-albums-partial.json",
+				}},
+				id: "51584178",
+			},
+			expected{
+				Unresolved: []string{"17927863", "51584178", "1234"},
+			},
+			false,
+		},
+		{
+			"Similar albums endpoint error",
+			args{
+				httpClient: &mockRoutedHTTPClient{},
+				id:         "51584178",
+			},
+			expected{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{httpClient: tt.args.httpClient, CountryCode: countryCode}
+
+			result, err := client.GetSimilarAlbumsResolved(context.Background(), tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetSimilarAlbumsResolved() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var albumIDs []string
+			for _, album := range result.Albums {
+				albumIDs = append(albumIDs, album.ID)
+			}
+
+			if !reflect.DeepEqual(albumIDs, tt.expected.AlbumIDs) {
+				t.Errorf("Client.GetSimilarAlbumsResolved() Albums = %v, want %v", albumIDs, tt.expected.AlbumIDs)
+			}
+
+			if !reflect.DeepEqual(result.Unresolved, tt.expected.Unresolved) {
+				t.Errorf("Client.GetSimilarAlbumsResolved() Unresolved = %v, want %v", result.Unresolved, tt.expected.Unresolved)
+			}
+		})
+	}
+}
+
+func TestGetMultipleAlbumsBatches(t *testing.T) {
+	t.Parallel()
+
+	counting := &countingHTTPClient{
+		HTTPClient: &mockRoutedHTTPClient{Routes: map[string]string{"/albums/byIds": "testdata/multiple-albums.json"}},
+		counts:     map[string]int{},
+	}
+	client := &Client{httpClient: counting, CountryCode: countryCode}
+
+	ids := make([]string, 0, 2*multipleIDsLimit+5)
+	for i := 0; i < cap(ids); i++ {
+		ids = append(ids, strconv.Itoa(i))
+	}
+
+	albums, err := client.GetMultipleAlbums(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("Client.GetMultipleAlbums() error = %v", err)
+	}
+
+	if requests := counting.counts["/albums/byIds"]; requests != 3 {
+		t.Errorf("Client.GetMultipleAlbums() made %d requests, want 3", requests)
+	}
+
+	if len(albums) != 9 {
+		t.Errorf("Client.GetMultipleAlbums() returned %d albums, want 9", len(albums))
+	}
+
+	if batches := BatchIDs(ids); len(batches) != 3 || len(batches[0]) != multipleIDsLimit {
+		t.Errorf("BatchIDs() = %d batches, want 3 of up to %d IDs", len(batches), multipleIDsLimit)
+	}
+}
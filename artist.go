@@ -67,8 +67,13 @@ type artistResults struct {
 	Data []Artist `json:"data"`
 }
 
-// GetMultipleArtists returns a list of artists filtered by their IDs.
+// GetMultipleArtists returns a list of artists filtered by their IDs. Any number of IDs can be given; they are
+// fetched in batches the endpoint accepts.
 func (c *Client) GetMultipleArtists(ctx context.Context, ids []string) ([]Artist, error) {
+	return fetchInBatches(ctx, ids, c.multipleArtists)
+}
+
+func (c *Client) multipleArtists(ctx context.Context, ids []string) ([]Artist, error) {
 	type multiArtistParams struct {
 		ids string
 	}
@@ -105,16 +110,22 @@ type similarArtistResults struct {
 
 // GetSimilarArtists returns a slice of artist IDs that can be used as a parameter in the GetMultipleArtists function.
 func (c *Client) GetSimilarArtists(ctx context.Context, id string, params PaginationParams) ([]string, error) {
+	artistIDs, _, err := c.similarArtistIDs(ctx, id, params)
+
+	return artistIDs, err
+}
+
+func (c *Client) similarArtistIDs(ctx context.Context, id string, params PaginationParams) ([]string, int, error) {
 	response, err := c.request(ctx, http.MethodGet, concat("/artists/", id, "/similar"), params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to the similar artists endpoint: %w", err)
+		return nil, 0, fmt.Errorf("failed to connect to the similar artists endpoint: %w", err)
 	}
 
 	var results similarArtistResults
 
 	err = json.Unmarshal(response, &results)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal the similar artists response body: %w", err)
+		return nil, 0, fmt.Errorf("failed to unmarshal the similar artists response body: %w", err)
 	}
 
 	var artistIDs []string
@@ -122,5 +133,39 @@ func (c *Client) GetSimilarArtists(ctx context.Context, id string, params Pagina
 		artistIDs = append(artistIDs, artistID.Resource.ID)
 	}
 
-	return artistIDs, nil
+	return artistIDs, results.MetaData.Total, nil
+}
+
+// SimilarArtists contains the hydrated results of a similar artists lookup.
+type SimilarArtists struct {
+	// Artists are ordered by similarity rank, most similar first.
+	Artists []Artist
+
+	// Unresolved lists the IDs of similar artists that were not returned by the multiple artists endpoint, or whose
+	// request failed, in similarity rank order.
+	Unresolved []string
+}
+
+// GetSimilarArtistsResolved returns every artist similar to an artist ID as full Artist values.
+//
+// The similar artists endpoint only returns IDs so this pages through all of them and fetches the artists in batches
+// using the multiple artists endpoint. Artists are returned in similarity rank order and any IDs that could not be
+// fetched, including every ID of a batch that failed, are reported in Unresolved rather than failing the whole call.
+func (c *Client) GetSimilarArtistsResolved(ctx context.Context, id string) (*SimilarArtists, error) {
+	if id == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	listIDs := func(ctx context.Context, params PaginationParams) ([]string, int, error) {
+		return c.similarArtistIDs(ctx, id, params)
+	}
+
+	artistID := func(artist Artist) string { return artist.ID }
+
+	artists, unresolved, err := resolveSimilar(ctx, listIDs, c.multipleArtists, artistID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimilarArtists{Artists: artists, Unresolved: unresolved}, nil
 }
@@ -3,6 +3,7 @@ package gotidal
 import (
 	"context"
 	"net/http"
+	"reflect"
 	"testing"
 )
 
@@ -223,3 +224,80 @@ func TestSimilarArtists(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSimilarArtistsResolved(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		httpClient HTTPClient
+		id         string
+	}
+
+	type expected struct {
+		ArtistIDs  []string
+		Unresolved []string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		wantErr  bool
+	}{
+		{
+			"Missing ID",
+			args{
+				httpClient: &mockRoutedHTTPClient{},
+				id:         "",
+			},
+			expected{},
+			true,
+		},
+		{
+			"Similar artists resolve in rank order",
+			args{
+				httpClient: &mockRoutedHTTPClient{Routes: map[string]string{
+					"/artists/3566512/similar": "testdata/similar-artists-partial.json",
+					"/artists":                 "testdata/multiple-artists.json",
+				}},
+				id: "3566512",
+			},
+			expected{
+				ArtistIDs:  []string{"31874", "5907"},
+				Unresolved: []string{"9999999"},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{httpClient: tt.args.httpClient}
+
+			result, err := client.GetSimilarArtistsResolved(context.Background(), tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetSimilarArtistsResolved() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var artistIDs []string
+			for _, artist := range result.Artists {
+				artistIDs = append(artistIDs, artist.ID)
+			}
+
+			if !reflect.DeepEqual(artistIDs, tt.expected.ArtistIDs) {
+				t.Errorf("Client.GetSimilarArtistsResolved() Artists = %v, want %v", artistIDs, tt.expected.ArtistIDs)
+			}
+
+			if !reflect.DeepEqual(result.Unresolved, tt.expected.Unresolved) {
+				t.Errorf("Client.GetSimilarArtistsResolved() Unresolved = %v, want %v", result.Unresolved, tt.expected.Unresolved)
+			}
+		})
+	}
+}
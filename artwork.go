@@ -0,0 +1,221 @@
+package gotidal
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Registers the JPEG decoder used to read artwork dimensions.
+	_ "image/png"  // Registers the PNG decoder used to read artwork dimensions.
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	artworkIndexFile = "index.json"
+	artworkFilePerm  = 0o644
+	artworkDirPerm   = 0o755
+
+	// artworkShardLength is the number of hash characters used to spread artwork across subdirectories.
+	artworkShardLength = 2
+)
+
+var ErrInvalidArtwork = errors.New("the downloaded artwork is not a valid image")
+
+// ArtworkOptions controls how artwork is downloaded.
+type ArtworkOptions struct {
+	// RateLimit applies to the downloads.
+	RateLimit
+}
+
+// ArtworkResult is the outcome of downloading a single image.
+type ArtworkResult struct {
+	Image Image
+
+	// Path is where the image is stored. Images with the same content share a path.
+	Path string
+
+	// Skipped reports whether the image had already been downloaded.
+	Skipped bool
+
+	Err error
+}
+
+// DownloadArtwork downloads images, such as those returned by BestCover and BestPicture, into dir.
+//
+// Images are stored by the SHA-256 hash of their content, so identical images are only stored once. An index of the
+// downloaded URLs lets later calls skip images that are already present without requesting them again. Each image is
+// checked to be a JPEG or PNG whose dimensions match the Image, and failures are reported in the result for that
+// image without stopping the other downloads.
+func (c *Client) DownloadArtwork(
+	ctx context.Context, dir string, images []Image, options ArtworkOptions,
+) ([]ArtworkResult, error) {
+	err := os.MkdirAll(dir, artworkDirPerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the artwork directory: %w", err)
+	}
+
+	index, err := loadArtworkIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ArtworkResult, len(images))
+
+	var mu sync.Mutex
+
+	runLimited(ctx, len(images), options.Concurrency, options.Interval, func(i int, err error) {
+		results[i].Image = images[i]
+
+		mu.Lock()
+		path, ok := index[images[i].URL]
+		mu.Unlock()
+
+		if ok && fileExists(filepath.Join(dir, path)) {
+			results[i].Path, results[i].Skipped = filepath.Join(dir, path), true
+
+			return
+		}
+
+		if err == nil {
+			path, err = c.downloadArtwork(ctx, dir, images[i])
+		}
+
+		if err != nil {
+			results[i].Err = err
+
+			return
+		}
+
+		mu.Lock()
+		index[images[i].URL] = path
+		mu.Unlock()
+
+		results[i].Path = filepath.Join(dir, path)
+	})
+
+	err = saveArtworkIndex(dir, index)
+	if err != nil {
+		return results, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return results, fmt.Errorf("artwork download stopped: %w", err)
+	}
+
+	return results, nil
+}
+
+// downloadArtwork fetches and checks an image, and stores it under its content hash. It returns the path of the
+// image relative to dir.
+func (c *Client) downloadArtwork(ctx context.Context, dir string, artwork Image) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, artwork.URL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request for %s: %w", artwork.URL, err)
+	}
+
+	response, err := doRequest(c.httpClient, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", artwork.URL, err)
+	}
+
+	extension, err := checkArtwork(response, artwork)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", artwork.URL, err)
+	}
+
+	sum := sha256.Sum256(response.Body)
+	hash := hex.EncodeToString(sum[:])
+	path := filepath.Join(hash[:artworkShardLength], concat(hash, extension))
+
+	if fileExists(filepath.Join(dir, path)) {
+		return path, nil
+	}
+
+	err = os.MkdirAll(filepath.Join(dir, hash[:artworkShardLength]), artworkDirPerm)
+	if err != nil {
+		return "", fmt.Errorf("failed to create the artwork directory: %w", err)
+	}
+
+	err = writeFileAtomic(filepath.Join(dir, path), response.Body, artworkFilePerm)
+	if err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// checkArtwork checks that a response is a JPEG or PNG image of the expected size and returns its file extension.
+func checkArtwork(response *apiResponse, artwork Image) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "image/") {
+		return "", fmt.Errorf("%w: unexpected content type %q", ErrInvalidArtwork, response.Header.Get("Content-Type"))
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(response.Body))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidArtwork, err)
+	}
+
+	if concat("image/", format) != mediaType {
+		return "", fmt.Errorf("%w: %s content served as %s", ErrInvalidArtwork, format, mediaType)
+	}
+
+	if (artwork.Width > 0 && config.Width != artwork.Width) || (artwork.Height > 0 && config.Height != artwork.Height) {
+		return "", fmt.Errorf(
+			"%w: the image is %dx%d, want %dx%d",
+			ErrInvalidArtwork, config.Width, config.Height, artwork.Width, artwork.Height,
+		)
+	}
+
+	if format == "jpeg" {
+		return ".jpg", nil
+	}
+
+	return concat(".", format), nil
+}
+
+// loadArtworkIndex reads the map of downloaded URLs to their paths relative to dir.
+func loadArtworkIndex(dir string) (map[string]string, error) {
+	index := map[string]string{}
+
+	data, err := os.ReadFile(filepath.Join(dir, artworkIndexFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return index, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the artwork index: %w", err)
+	}
+
+	err = json.Unmarshal(data, &index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the artwork index: %w", err)
+	}
+
+	return index, nil
+}
+
+func saveArtworkIndex(dir string, index map[string]string) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the artwork index: %w", err)
+	}
+
+	return writeFileAtomic(filepath.Join(dir, artworkIndexFile), data, artworkFilePerm)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
@@ -0,0 +1,114 @@
+package gotidal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	err := png.Encode(&buffer, image.NewGray(image.Rect(0, 0, width, height)))
+	if err != nil {
+		t.Fatalf("failed to encode the test image: %v", err)
+	}
+
+	return buffer.Bytes()
+}
+
+func TestClient_DownloadArtwork(t *testing.T) {
+	t.Parallel()
+
+	small, large := encodePNG(t, 2, 2), encodePNG(t, 3, 3)
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		switch r.URL.Path {
+		case "/cover.png", "/copy.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(small)
+		case "/large.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(large)
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<html></html>"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client()}
+	dir := t.TempDir()
+
+	images := []Image{
+		{URL: server.URL + "/cover.png", Width: 2, Height: 2},
+		{URL: server.URL + "/copy.png", Width: 2, Height: 2},
+		{URL: server.URL + "/large.png", Width: 2, Height: 2},
+		{URL: server.URL + "/page.html"},
+		{URL: server.URL + "/missing.png"},
+	}
+
+	options := ArtworkOptions{RateLimit: RateLimit{Concurrency: 2}}
+
+	results, err := client.DownloadArtwork(context.Background(), dir, images, options)
+	if err != nil {
+		t.Fatalf("Client.DownloadArtwork() error = %v", err)
+	}
+
+	for _, result := range results[:2] {
+		if result.Err != nil || result.Skipped {
+			t.Errorf("Client.DownloadArtwork(%s) = %+v, want a download", result.Image.URL, result)
+		}
+
+		data, err := os.ReadFile(result.Path)
+		if err != nil || !bytes.Equal(data, small) {
+			t.Errorf("Client.DownloadArtwork(%s) stored %d bytes, error %v", result.Image.URL, len(data), err)
+		}
+	}
+
+	if results[0].Path != results[1].Path {
+		t.Errorf("Client.DownloadArtwork() stored identical images at %s and %s", results[0].Path, results[1].Path)
+	}
+
+	for _, result := range results[2:4] {
+		if !errors.Is(result.Err, ErrInvalidArtwork) {
+			t.Errorf("Client.DownloadArtwork(%s) error = %v, want %v", result.Image.URL, result.Err, ErrInvalidArtwork)
+		}
+	}
+
+	if !errors.Is(results[4].Err, ErrUnexpectedResponseCode) {
+		t.Errorf("Client.DownloadArtwork() error = %v, want %v", results[4].Err, ErrUnexpectedResponseCode)
+	}
+
+	atomic.StoreInt32(&requests, 0)
+
+	results, err = client.DownloadArtwork(context.Background(), dir, images[:2], ArtworkOptions{})
+	if err != nil {
+		t.Fatalf("Client.DownloadArtwork() error = %v", err)
+	}
+
+	for _, result := range results {
+		if !result.Skipped || result.Err != nil {
+			t.Errorf("Client.DownloadArtwork(%s) = %+v, want it skipped", result.Image.URL, result)
+		}
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 0 {
+		t.Errorf("Client.DownloadArtwork() sent %d requests for images that were already downloaded", got)
+	}
+}
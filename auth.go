@@ -0,0 +1,426 @@
+package gotidal
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	authorizeURI = "https://login.tidal.com/authorize"
+
+	// expiryLeeway refreshes user tokens slightly before they expire so in-flight requests don't fail.
+	expiryLeeway = 30 * time.Second
+
+	// randomBytesLength is the number of random bytes used for PKCE verifiers and state values.
+	randomBytesLength = 32
+)
+
+// Scopes that can be requested when authorizing a user.
+// See: https://developer.tidal.com/documentation/authorization/authorization-scopes
+const (
+	ScopeUserRead            = "user.read"
+	ScopeCollectionRead      = "collection.read"
+	ScopeCollectionWrite     = "collection.write"
+	ScopePlaylistsRead       = "playlists.read"
+	ScopePlaylistsWrite      = "playlists.write"
+	ScopeRecommendationsRead = "recommendations.read"
+)
+
+var (
+	ErrStateMismatch        = errors.New("the callback state does not match the authorization request")
+	ErrAuthorizationDenied  = errors.New("the user did not grant authorization")
+	ErrMissingCode          = errors.New("the callback did not contain an authorization code")
+	ErrMissingRefreshToken  = errors.New("the token cannot be refreshed as it has no refresh token")
+	ErrMissingAuthorization = errors.New("a user token is required to make this request")
+)
+
+// OAuthConfig defines the parameters needed to authorize a user with TIDAL.
+type OAuthConfig struct {
+	ClientID string
+
+	// ClientSecret is optional as public clients authenticate the code exchange with PKCE alone.
+	ClientSecret string
+
+	// RedirectURI must exactly match one of the redirect URIs registered for the client.
+	// Example: http://localhost:8080/callback
+	RedirectURI string
+
+	Scopes []string
+
+	// AuthorizeURL, TokenURL and DeviceAuthorizationURL override the TIDAL endpoints. They default to the production
+	// endpoints when empty.
+	AuthorizeURL           string
+	TokenURL               string
+	DeviceAuthorizationURL string
+}
+
+func (c OAuthConfig) authorizeURL() string {
+	if c.AuthorizeURL != "" {
+		return c.AuthorizeURL
+	}
+
+	return authorizeURI
+}
+
+func (c OAuthConfig) tokenURL() string {
+	if c.TokenURL != "" {
+		return c.TokenURL
+	}
+
+	return oauthURI
+}
+
+// PKCE holds the proof key for a single authorization request.
+// See: https://datatracker.ietf.org/doc/html/rfc7636
+type PKCE struct {
+	Verifier  string
+	Challenge string
+}
+
+// NewPKCE generates a random code verifier and its S256 code challenge.
+func NewPKCE() (*PKCE, error) {
+	verifier, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256([]byte(verifier))
+
+	return &PKCE{
+		Verifier:  verifier,
+		Challenge: base64.RawURLEncoding.EncodeToString(hash[:]),
+	}, nil
+}
+
+// NewState generates a random state value to protect the authorization request against CSRF.
+func NewState() (string, error) {
+	return randomString()
+}
+
+func randomString() (string, error) {
+	buffer := make([]byte, randomBytesLength)
+
+	_, err := rand.Read(buffer)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buffer), nil
+}
+
+// AuthCodeURL returns the URL the user should visit to authorize the client.
+func (c OAuthConfig) AuthCodeURL(state string, pkce *PKCE) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", c.ClientID)
+	params.Set("redirect_uri", c.RedirectURI)
+	params.Set("scope", strings.Join(c.Scopes, " "))
+	params.Set("state", state)
+	params.Set("code_challenge_method", "S256")
+	params.Set("code_challenge", pkce.Challenge)
+
+	return concat(c.authorizeURL(), "?", params.Encode())
+}
+
+// UserToken represents an access token issued on behalf of a user.
+type UserToken struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken"`
+	TokenType    string    `json:"tokenType"`
+	Scope        string    `json:"scope"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// Valid reports whether the access token is present and not about to expire.
+func (t *UserToken) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+
+	return t.Expiry.IsZero() || time.Now().Add(expiryLeeway).Before(t.Expiry)
+}
+
+// Exchange swaps an authorization code from the callback for a user token.
+func (c OAuthConfig) Exchange(ctx context.Context, httpClient HTTPClient, code string, pkce *PKCE) (*UserToken, error) {
+	if code == "" {
+		return nil, ErrMissingCode
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("client_id", c.ClientID)
+	form.Set("code", code)
+	form.Set("redirect_uri", c.RedirectURI)
+	form.Set("code_verifier", pkce.Verifier)
+
+	return c.requestToken(ctx, httpClient, form)
+}
+
+// Refresh uses the refresh token of a user token to obtain a new access token.
+func (c OAuthConfig) Refresh(ctx context.Context, httpClient HTTPClient, token *UserToken) (*UserToken, error) {
+	if token == nil || token.RefreshToken == "" {
+		return nil, ErrMissingRefreshToken
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("client_id", c.ClientID)
+	form.Set("refresh_token", token.RefreshToken)
+
+	refreshed, err := c.requestToken(ctx, httpClient, form)
+	if err != nil {
+		return nil, err
+	}
+
+	// TIDAL does not always rotate refresh tokens, in which case the original one remains valid.
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+
+	return refreshed, nil
+}
+
+func (c OAuthConfig) requestToken(ctx context.Context, httpClient HTTPClient, form url.Values) (*UserToken, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL(), bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OAuth request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if c.ClientSecret != "" {
+		basicAuth := base64.StdEncoding.EncodeToString([]byte(concat(c.ClientID, ":", c.ClientSecret)))
+		req.Header.Set("Authorization", concat("Basic ", basicAuth))
+	}
+
+	responseBody, err := processRequest(httpClient, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process the request: %w", err)
+	}
+
+	var authResponse authResponse
+
+	err = json.Unmarshal(responseBody, &authResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the OAuth response body: %w", err)
+	}
+
+	return authResponse.userToken(), nil
+}
+
+// CallbackHandler receives the redirect from the TIDAL login page on a loopback address and captures the
+// authorization code.
+//
+// Only the first callback is captured; subsequent requests are answered but ignored.
+type CallbackHandler struct {
+	state  string
+	result chan callbackResult
+}
+
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// NewCallbackHandler returns a handler that accepts callbacks matching the state of the authorization request.
+func NewCallbackHandler(state string) *CallbackHandler {
+	return &CallbackHandler{
+		state:  state,
+		result: make(chan callbackResult, 1),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var result callbackResult
+
+	switch {
+	case query.Get("state") != h.state:
+		result.err = ErrStateMismatch
+	case query.Get("error") != "":
+		result.err = fmt.Errorf("%w: %s %s", ErrAuthorizationDenied, query.Get("error"), query.Get("error_description"))
+	case query.Get("code") == "":
+		result.err = ErrMissingCode
+	default:
+		result.code = query.Get("code")
+	}
+
+	select {
+	case h.result <- result:
+	default:
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	if result.err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("Authorization failed. You can close this window."))
+
+		return
+	}
+
+	_, _ = w.Write([]byte("Authorization complete. You can close this window."))
+}
+
+// Wait blocks until the callback is received or the context is done, and returns the authorization code.
+func (h *CallbackHandler) Wait(ctx context.Context) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", fmt.Errorf("stopped waiting for the authorization callback: %w", ctx.Err())
+	case result := <-h.result:
+		return result.code, result.err
+	}
+}
+
+// refreshingTokenSource keeps a token fresh, refreshing it when it expires and persisting it to an optional
+// TokenStore.
+type refreshingTokenSource struct {
+	mu      sync.Mutex
+	token   *UserToken
+	store   TokenStore
+	refresh func(ctx context.Context, token *UserToken) (*UserToken, error)
+}
+
+func (s *refreshingTokenSource) Token(ctx context.Context) (*UserToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() {
+		return s.token, nil
+	}
+
+	if locker, ok := s.store.(TokenLocker); ok {
+		unlock, err := locker.Lock(ctx)
+		if err != nil {
+			return nil, err // nolint:wrapcheck // The store errors describe the lock.
+		}
+		defer unlock()
+	}
+
+	// Another process sharing the store may have refreshed the token already, in which case its refresh token could
+	// have been rotated and ours would no longer work.
+	stored := s.load(ctx)
+	if stored.Valid() {
+		s.token = stored
+
+		return stored, nil
+	}
+
+	current := s.token
+	if stored != nil && stored.RefreshToken != "" {
+		current = stored
+	}
+
+	token, err := s.refresh(ctx, current)
+	if err != nil {
+		stored = s.load(ctx)
+		if stored.Valid() {
+			s.token = stored
+
+			return stored, nil
+		}
+
+		// The token is only deleted if it is still the one that was rejected, so a token saved by another client in
+		// the meantime is kept.
+		if stored != nil && current != nil && stored.RefreshToken == current.RefreshToken &&
+			oauthErrorCode(err) == "invalid_grant" {
+			_ = s.store.Delete(ctx)
+		}
+
+		return nil, fmt.Errorf("failed to refresh the token: %w", err)
+	}
+
+	s.token = token
+
+	if s.store != nil {
+		err = s.store.Save(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save the refreshed token: %w", err)
+		}
+	}
+
+	return token, nil
+}
+
+// load returns the token held by the store, or nil if there is no store or it has no token.
+func (s *refreshingTokenSource) load(ctx context.Context) *UserToken {
+	if s.store == nil {
+		return nil
+	}
+
+	token, err := s.store.Load(ctx)
+	if err != nil {
+		return nil
+	}
+
+	return token
+}
+
+// NewUserClient returns an API client that acts on behalf of a user. The token is refreshed automatically when it
+// expires.
+func NewUserClient(config OAuthConfig, token *UserToken, countryCode string) (*Client, error) {
+	code, err := ParseCountryCode(countryCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUserClient(config, token, nil, string(code)), nil
+}
+
+func newUserClient(config OAuthConfig, token *UserToken, store TokenStore, countryCode string) *Client {
+	httpClient := &http.Client{}
+
+	return &Client{
+		httpClient:    httpClient,
+		ContentType:   contentType,
+		Environment:   environment,
+		EnvironmentV2: environmentV2,
+		CountryCode:   countryCode,
+		MaxRetries:    defaultMaxRetries,
+		cache:         newResponseCache(),
+		tokenSource: &refreshingTokenSource{
+			token: token,
+			store: store,
+			refresh: func(ctx context.Context, token *UserToken) (*UserToken, error) {
+				return config.Refresh(ctx, httpClient, token)
+			},
+		},
+	}
+}
+
+// UserToken returns the current user token of a client created with NewUserClient, refreshing it if required, so it
+// can be persisted between sessions.
+func (c *Client) UserToken(ctx context.Context) (*UserToken, error) {
+	if c.tokenSource == nil {
+		return nil, ErrMissingAuthorization
+	}
+
+	return c.tokenSource.Token(ctx)
+}
+
+// authorization returns the value of the Authorization header for API requests.
+func (c *Client) authorization(ctx context.Context) (string, error) {
+	if c.tokenSource == nil {
+		return concat("Bearer ", c.Token), nil
+	}
+
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return concat("Bearer ", token.AccessToken), nil
+}
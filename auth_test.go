@@ -0,0 +1,342 @@
+package gotidal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewPKCE(t *testing.T) {
+	t.Parallel()
+
+	pkce, err := NewPKCE()
+	if err != nil {
+		t.Fatalf("NewPKCE() error = %v", err)
+	}
+
+	hash := sha256.Sum256([]byte(pkce.Verifier))
+	if want := base64.RawURLEncoding.EncodeToString(hash[:]); pkce.Challenge != want {
+		t.Errorf("NewPKCE() Challenge = %v, want %v", pkce.Challenge, want)
+	}
+
+	other, err := NewPKCE()
+	if err != nil {
+		t.Fatalf("NewPKCE() error = %v", err)
+	}
+
+	if pkce.Verifier == other.Verifier {
+		t.Error("NewPKCE() generated the same verifier twice")
+	}
+}
+
+func TestOAuthConfig_AuthCodeURL(t *testing.T) {
+	t.Parallel()
+
+	config := OAuthConfig{
+		ClientID:    "client-id",
+		RedirectURI: "http://localhost:8080/callback",
+		Scopes:      []string{ScopeUserRead, ScopePlaylistsRead},
+	}
+
+	authURL, err := url.Parse(config.AuthCodeURL("state-value", &PKCE{Verifier: "verifier", Challenge: "challenge"}))
+	if err != nil {
+		t.Fatalf("OAuthConfig.AuthCodeURL() returned an invalid URL: %v", err)
+	}
+
+	if got := concat(authURL.Scheme, "://", authURL.Host, authURL.Path); got != authorizeURI {
+		t.Errorf("OAuthConfig.AuthCodeURL() endpoint = %v, want %v", got, authorizeURI)
+	}
+
+	expected := map[string]string{
+		"response_type":         "code",
+		"client_id":             "client-id",
+		"redirect_uri":          "http://localhost:8080/callback",
+		"scope":                 "user.read playlists.read",
+		"state":                 "state-value",
+		"code_challenge_method": "S256",
+		"code_challenge":        "challenge",
+	}
+
+	for key, want := range expected {
+		if got := authURL.Query().Get(key); got != want {
+			t.Errorf("OAuthConfig.AuthCodeURL() %s = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestCallbackHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		query      string
+		wantCode   string
+		wantErr    error
+		wantStatus int
+	}{
+		{
+			"Valid callback",
+			"code=abc&state=xyz",
+			"abc",
+			nil,
+			http.StatusOK,
+		},
+		{
+			"State mismatch",
+			"code=abc&state=other",
+			"",
+			ErrStateMismatch,
+			http.StatusBadRequest,
+		},
+		{
+			"Access denied",
+			"error=access_denied&state=xyz",
+			"",
+			ErrAuthorizationDenied,
+			http.StatusBadRequest,
+		},
+		{
+			"Missing code",
+			"state=xyz",
+			"",
+			ErrMissingCode,
+			http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := NewCallbackHandler("xyz")
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, concat("/callback?", tt.query), nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("CallbackHandler.ServeHTTP() status = %v, want %v", recorder.Code, tt.wantStatus)
+			}
+
+			code, err := handler.Wait(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CallbackHandler.Wait() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if code != tt.wantCode {
+				t.Errorf("CallbackHandler.Wait() = %v, want %v", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCallbackHandler_WaitCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewCallbackHandler("xyz").Wait(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CallbackHandler.Wait() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func newTokenServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseForm()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var filePath string
+
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			if r.PostForm.Get("code") != "abc" || r.PostForm.Get("code_verifier") != "verifier" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			filePath = "testdata/user-token.json"
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "user-refresh-token" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			filePath = "testdata/refreshed-token.json"
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write(data)
+	}))
+}
+
+func TestOAuthConfig_Exchange(t *testing.T) {
+	t.Parallel()
+
+	server := newTokenServer(t)
+	defer server.Close()
+
+	config := OAuthConfig{ClientID: "client-id", TokenURL: server.URL}
+	pkce := &PKCE{Verifier: "verifier"}
+
+	token, err := config.Exchange(context.Background(), server.Client(), "abc", pkce)
+	if err != nil {
+		t.Fatalf("OAuthConfig.Exchange() error = %v", err)
+	}
+
+	if token.AccessToken != "user-access-token" || token.RefreshToken != "user-refresh-token" {
+		t.Errorf("OAuthConfig.Exchange() = %+v", token)
+	}
+
+	if !token.Valid() {
+		t.Error("OAuthConfig.Exchange() returned an invalid token")
+	}
+
+	_, err = config.Exchange(context.Background(), server.Client(), "wrong", pkce)
+	if !errors.Is(err, ErrUnexpectedResponseCode) {
+		t.Errorf("OAuthConfig.Exchange() error = %v, want %v", err, ErrUnexpectedResponseCode)
+	}
+}
+
+func TestOAuthConfig_Refresh(t *testing.T) {
+	t.Parallel()
+
+	server := newTokenServer(t)
+	defer server.Close()
+
+	config := OAuthConfig{ClientID: "client-id", TokenURL: server.URL}
+
+	_, err := config.Refresh(context.Background(), server.Client(), &UserToken{AccessToken: "expired"})
+	if !errors.Is(err, ErrMissingRefreshToken) {
+		t.Errorf("OAuthConfig.Refresh() error = %v, want %v", err, ErrMissingRefreshToken)
+	}
+
+	token, err := config.Refresh(context.Background(), server.Client(), &UserToken{RefreshToken: "user-refresh-token"})
+	if err != nil {
+		t.Fatalf("OAuthConfig.Refresh() error = %v", err)
+	}
+
+	if token.AccessToken != "refreshed-access-token" {
+		t.Errorf("OAuthConfig.Refresh() AccessToken = %v, want %v", token.AccessToken, "refreshed-access-token")
+	}
+
+	if token.RefreshToken != "user-refresh-token" {
+		t.Errorf("OAuthConfig.Refresh() RefreshToken = %v, want %v", token.RefreshToken, "user-refresh-token")
+	}
+}
+
+func TestClient_userTokenRefresh(t *testing.T) {
+	t.Parallel()
+
+	tokenServer := newTokenServer(t)
+	defer tokenServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer refreshed-access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		data, err := os.ReadFile("testdata/single-artist.json")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write(data)
+	}))
+	defer apiServer.Close()
+
+	client, err := NewUserClient(
+		OAuthConfig{ClientID: "client-id", TokenURL: tokenServer.URL},
+		&UserToken{
+			AccessToken:  "expired-access-token",
+			RefreshToken: "user-refresh-token",
+			Expiry:       time.Now().Add(-time.Hour),
+		},
+		countryCode,
+	)
+	if err != nil {
+		t.Fatalf("NewUserClient() error = %v", err)
+	}
+
+	client.Environment = apiServer.URL
+
+	artist, err := client.GetSingleArtist(context.Background(), "5907")
+	if err != nil {
+		t.Fatalf("Client.GetSingleArtist() error = %v", err)
+	}
+
+	if artist.Name != "Kronos Quartet" {
+		t.Errorf("Client.GetSingleArtist() Name = %v, want %v", artist.Name, "Kronos Quartet")
+	}
+
+	token, err := client.UserToken(context.Background())
+	if err != nil {
+		t.Fatalf("Client.UserToken() error = %v", err)
+	}
+
+	if token.AccessToken != "refreshed-access-token" {
+		t.Errorf("Client.UserToken() AccessToken = %v, want %v", token.AccessToken, "refreshed-access-token")
+	}
+}
+
+func TestClient_userTokenV2(t *testing.T) {
+	t.Parallel()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer user-access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		data, err := os.ReadFile("testdata/v2-track.json")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write(data)
+	}))
+	defer apiServer.Close()
+
+	client, err := NewUserClient(
+		OAuthConfig{ClientID: "client-id"},
+		&UserToken{AccessToken: "user-access-token", Expiry: time.Now().Add(time.Hour)},
+		countryCode,
+	)
+	if err != nil {
+		t.Fatalf("NewUserClient() error = %v", err)
+	}
+
+	client.EnvironmentV2 = apiServer.URL
+
+	track, err := client.GetTrackV2(context.Background(), "51584179", V2Params{})
+	if err != nil {
+		t.Fatalf("Client.GetTrackV2() error = %v", err)
+	}
+
+	if track.Title != "Age of Consent" {
+		t.Errorf("Client.GetTrackV2() Title = %v, want %v", track.Title, "Age of Consent")
+	}
+}
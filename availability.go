@@ -0,0 +1,217 @@
+package gotidal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	AvailabilityAvailable   = "AVAILABLE"
+	AvailabilityUnavailable = "UNAVAILABLE"
+	AvailabilityError       = "ERROR"
+)
+
+// AvailabilityResult is the availability of a single resource in a single country. Err is set when the status is
+// AvailabilityError.
+type AvailabilityResult struct {
+	Status string
+	Err    error
+}
+
+// AvailabilityMatrix holds the availability of each resource ID in each country.
+type AvailabilityMatrix struct {
+	IDs          []string
+	CountryCodes []string
+
+	// Results is keyed by country code and then by resource ID.
+	Results map[string]map[string]AvailabilityResult
+}
+
+// Result returns the availability of an ID in a country.
+func (m *AvailabilityMatrix) Result(id string, countryCode string) AvailabilityResult {
+	return m.Results[countryCode][id]
+}
+
+// AvailableIn returns the countries, in the order they were checked, where an ID is available.
+func (m *AvailabilityMatrix) AvailableIn(id string) []string {
+	var countryCodes []string
+
+	for _, countryCode := range m.CountryCodes {
+		if m.Results[countryCode][id].Status == AvailabilityAvailable {
+			countryCodes = append(countryCodes, countryCode)
+		}
+	}
+
+	return countryCodes
+}
+
+// AvailabilityDiff lists the IDs whose availability differs between two countries. IDs that could not be checked in
+// either country are listed in Unknown rather than being treated as unavailable.
+type AvailabilityDiff struct {
+	OnlyInA []string
+	OnlyInB []string
+	Unknown []string
+}
+
+// Diff compares the results of two countries.
+func (m *AvailabilityMatrix) Diff(countryCodeA string, countryCodeB string) AvailabilityDiff {
+	var diff AvailabilityDiff
+
+	for _, id := range m.IDs {
+		resultA, resultB := m.Result(id, countryCodeA), m.Result(id, countryCodeB)
+
+		switch {
+		case resultA.Status != AvailabilityAvailable && resultA.Status != AvailabilityUnavailable,
+			resultB.Status != AvailabilityAvailable && resultB.Status != AvailabilityUnavailable:
+			diff.Unknown = append(diff.Unknown, id)
+		case resultA.Status == resultB.Status:
+		case resultA.Status == AvailabilityAvailable:
+			diff.OnlyInA = append(diff.OnlyInA, id)
+		default:
+			diff.OnlyInB = append(diff.OnlyInB, id)
+		}
+	}
+
+	return diff
+}
+
+// GetAlbumAvailability checks which of the album IDs are available in each of the countries.
+func (c *Client) GetAlbumAvailability(
+	ctx context.Context, ids []string, countryCodes []string, limit RateLimit,
+) (*AvailabilityMatrix, error) {
+	return c.checkAvailability(ctx, ids, countryCodes, limit, c.availableAlbumIDs)
+}
+
+// GetTrackAvailability checks which of the track IDs are available in each of the countries.
+func (c *Client) GetTrackAvailability(
+	ctx context.Context, ids []string, countryCodes []string, limit RateLimit,
+) (*AvailabilityMatrix, error) {
+	return c.checkAvailability(ctx, ids, countryCodes, limit, c.availableTrackIDs)
+}
+
+func (c *Client) availableAlbumIDs(ctx context.Context, ids []string) ([]string, error) {
+	albums, err := c.multipleAlbums(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]string, 0, len(albums))
+	for _, album := range albums {
+		available = append(available, album.ID)
+	}
+
+	return available, nil
+}
+
+func (c *Client) availableTrackIDs(ctx context.Context, ids []string) ([]string, error) {
+	tracks, err := c.multipleTracks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]string, 0, len(tracks))
+	for _, track := range tracks {
+		available = append(available, track.ID)
+	}
+
+	return available, nil
+}
+
+type availabilityJob struct {
+	countryCode string
+	ids         []string
+}
+
+// checkAvailability fetches every batch of IDs in every country and records which IDs were returned. A failed batch
+// marks its IDs as errors without failing the other batches. The country codes are normalized to upper case, which is
+// how the matrix is keyed.
+func (c *Client) checkAvailability(
+	ctx context.Context, ids []string, countryCodes []string, limit RateLimit,
+	fetch func(ctx context.Context, batch []string) ([]string, error),
+) (*AvailabilityMatrix, error) {
+	if len(ids) == 0 || len(countryCodes) == 0 {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	countryCodes, err := parseCountryCodes(countryCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := &AvailabilityMatrix{
+		IDs:          ids,
+		CountryCodes: countryCodes,
+		Results:      make(map[string]map[string]AvailabilityResult, len(countryCodes)),
+	}
+
+	var jobs []availabilityJob
+
+	for _, countryCode := range countryCodes {
+		matrix.Results[countryCode] = make(map[string]AvailabilityResult, len(ids))
+
+		for _, batch := range chunkIDs(ids, multipleIDsLimit) {
+			jobs = append(jobs, availabilityJob{countryCode: countryCode, ids: batch})
+		}
+	}
+
+	var mu sync.Mutex
+
+	runLimited(ctx, len(jobs), limit.Concurrency, limit.Interval, func(i int, err error) {
+		job := jobs[i]
+
+		var available []string
+		if err == nil {
+			available, err = fetch(WithCountryCode(ctx, job.countryCode), job.ids)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		recordAvailability(matrix.Results[job.countryCode], job.ids, available, err)
+	})
+
+	if err := ctx.Err(); err != nil {
+		return matrix, fmt.Errorf("availability check stopped: %w", err)
+	}
+
+	return matrix, nil
+}
+
+func parseCountryCodes(codes []string) ([]string, error) {
+	countryCodes := make([]string, 0, len(codes))
+
+	for _, code := range codes {
+		countryCode, err := ParseCountryCode(code)
+		if err != nil {
+			return nil, err
+		}
+
+		countryCodes = append(countryCodes, string(countryCode))
+	}
+
+	return countryCodes, nil
+}
+
+func recordAvailability(results map[string]AvailabilityResult, ids []string, available []string, err error) {
+	if err != nil {
+		for _, id := range ids {
+			results[id] = AvailabilityResult{Status: AvailabilityError, Err: err}
+		}
+
+		return
+	}
+
+	sort.Strings(available)
+
+	for _, id := range ids {
+		status := AvailabilityUnavailable
+
+		if i := sort.SearchStrings(available, id); i < len(available) && available[i] == id {
+			status = AvailabilityAvailable
+		}
+
+		results[id] = AvailabilityResult{Status: status}
+	}
+}
@@ -0,0 +1,131 @@
+package gotidal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newAvailabilityServer returns every requested album in US, only the first one in DE, and fails in FR.
+func newAvailabilityServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+
+		switch r.URL.Query().Get("countryCode") {
+		case "DE":
+			ids = ids[:1]
+		case "FR":
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		var results albumResults
+		for _, id := range ids {
+			results.Data = append(results.Data, Album{AlbumResource{ID: id}})
+		}
+
+		_ = json.NewEncoder(w).Encode(results)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_GetAlbumAvailability(t *testing.T) {
+	t.Parallel()
+
+	server := newAvailabilityServer(t)
+	client := &Client{httpClient: server.Client(), Environment: server.URL, CountryCode: countryCode}
+
+	ids := []string{"1", "2", "3"}
+
+	matrix, err := client.GetAlbumAvailability(
+		context.Background(), ids, []string{"US", "de", " fr"}, RateLimit{Concurrency: 2},
+	)
+	if err != nil {
+		t.Fatalf("Client.GetAlbumAvailability() error = %v", err)
+	}
+
+	if want := []string{"US", "DE", "FR"}; !reflect.DeepEqual(matrix.CountryCodes, want) {
+		t.Errorf("AvailabilityMatrix.CountryCodes = %v, want %v", matrix.CountryCodes, want)
+	}
+
+	tests := []struct {
+		id          string
+		countryCode string
+		want        string
+	}{
+		{"1", "US", AvailabilityAvailable},
+		{"3", "US", AvailabilityAvailable},
+		{"1", "DE", AvailabilityAvailable},
+		{"2", "DE", AvailabilityUnavailable},
+		{"2", "FR", AvailabilityError},
+	}
+	for _, tt := range tests {
+		result := matrix.Result(tt.id, tt.countryCode)
+		if result.Status != tt.want {
+			t.Errorf("AvailabilityMatrix.Result(%s, %s) = %v, want %v", tt.id, tt.countryCode, result.Status, tt.want)
+		}
+
+		if (result.Err != nil) != (tt.want == AvailabilityError) {
+			t.Errorf("AvailabilityMatrix.Result(%s, %s) error = %v", tt.id, tt.countryCode, result.Err)
+		}
+	}
+
+	if got, want := matrix.AvailableIn("1"), []string{"US", "DE"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AvailabilityMatrix.AvailableIn() = %v, want %v", got, want)
+	}
+
+	want := AvailabilityDiff{OnlyInA: []string{"2", "3"}}
+	if got := matrix.Diff("US", "DE"); !reflect.DeepEqual(got, want) {
+		t.Errorf("AvailabilityMatrix.Diff(US, DE) = %+v, want %+v", got, want)
+	}
+
+	want = AvailabilityDiff{Unknown: ids}
+	if got := matrix.Diff("FR", "US"); !reflect.DeepEqual(got, want) {
+		t.Errorf("AvailabilityMatrix.Diff(FR, US) = %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_GetAlbumAvailabilityMissingParameters(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{CountryCode: countryCode}
+
+	_, err := client.GetAlbumAvailability(context.Background(), nil, []string{"US"}, RateLimit{})
+	if !errors.Is(err, ErrMissingRequiredParameters) {
+		t.Errorf("Client.GetAlbumAvailability() error = %v, want %v", err, ErrMissingRequiredParameters)
+	}
+
+	_, err = client.GetAlbumAvailability(context.Background(), []string{"1"}, []string{"XX"}, RateLimit{})
+	if !errors.Is(err, ErrInvalidCountryCode) {
+		t.Errorf("Client.GetAlbumAvailability() error = %v, want %v", err, ErrInvalidCountryCode)
+	}
+}
+
+func TestClient_GetAlbumAvailabilityCanceled(t *testing.T) {
+	t.Parallel()
+
+	server := newAvailabilityServer(t)
+	client := &Client{httpClient: server.Client(), Environment: server.URL, CountryCode: countryCode}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	matrix, err := client.GetAlbumAvailability(ctx, []string{"1"}, []string{"US"}, RateLimit{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Client.GetAlbumAvailability() error = %v, want %v", err, context.Canceled)
+	}
+
+	if got := matrix.Result("1", "US").Status; got != AvailabilityError {
+		t.Errorf("AvailabilityMatrix.Result() = %v, want %v", got, AvailabilityError)
+	}
+}
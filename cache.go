@@ -0,0 +1,123 @@
+package gotidal
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// responseCache shares the responses of GET requests. Concurrent requests for the same URL wait for a single
+// request to the API, and successful responses are reused until they expire.
+//
+// Keys are full request URLs, so responses for different countries and locales are cached separately.
+type responseCache struct {
+	mu       sync.Mutex
+	entries  map[string]cacheEntry
+	inflight map[string]*inflightRequest
+}
+
+type cacheEntry struct {
+	response *apiResponse
+	expiry   time.Time
+}
+
+type inflightRequest struct {
+	done     chan struct{}
+	response *apiResponse
+	err      error
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{
+		entries:  map[string]cacheEntry{},
+		inflight: map[string]*inflightRequest{},
+	}
+}
+
+// do returns the cached response for key, waits for a request for the same key that is already in flight, or calls
+// fn. Successful responses are cached for ttl; a zero ttl only deduplicates concurrent requests.
+func (rc *responseCache) do(
+	ctx context.Context, key string, ttl time.Duration, fn func() (*apiResponse, error),
+) (*apiResponse, error) {
+	for {
+		rc.mu.Lock()
+
+		if entry, ok := rc.entries[key]; ok {
+			if time.Now().Before(entry.expiry) {
+				rc.mu.Unlock()
+
+				return entry.response, nil
+			}
+
+			delete(rc.entries, key)
+		}
+
+		call, ok := rc.inflight[key]
+		if !ok {
+			call = &inflightRequest{done: make(chan struct{})}
+			rc.inflight[key] = call
+			rc.mu.Unlock()
+
+			return rc.run(key, ttl, call, fn)
+		}
+
+		rc.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err() // nolint:wrapcheck // Wrapped by the caller.
+		case <-call.done:
+		}
+
+		// The request was made with another caller's context. If that caller gave up, make the request again
+		// rather than failing a caller that is still waiting.
+		if isContextError(call.err) && ctx.Err() == nil {
+			continue
+		}
+
+		return call.response, call.err
+	}
+}
+
+func (rc *responseCache) run(
+	key string, ttl time.Duration, call *inflightRequest, fn func() (*apiResponse, error),
+) (*apiResponse, error) {
+	call.response, call.err = fn()
+
+	rc.mu.Lock()
+	delete(rc.inflight, key)
+
+	if call.err == nil && ttl > 0 {
+		now := time.Now()
+		rc.sweep(now)
+		rc.entries[key] = cacheEntry{response: call.response, expiry: now.Add(ttl)}
+	}
+	rc.mu.Unlock()
+
+	close(call.done)
+
+	return call.response, call.err
+}
+
+// sweep removes the entries that expired before now, so responses that are never requested again do not stay in
+// memory. The caller must hold rc.mu.
+func (rc *responseCache) sweep(now time.Time) {
+	for key, entry := range rc.entries {
+		if !now.Before(entry.expiry) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// clear removes every cached response. It is called after a write so later reads see the change.
+func (rc *responseCache) clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries = map[string]cacheEntry{}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
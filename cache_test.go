@@ -0,0 +1,197 @@
+package gotidal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCacheTestClient(t *testing.T, ttl time.Duration, release <-chan struct{}) (*Client, *int32) {
+	t.Helper()
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		if release != nil {
+			<-release
+		}
+
+		if r.Method != http.MethodGet {
+			if r.Header.Get("If-Match") == "stale" {
+				w.WriteHeader(http.StatusPreconditionFailed)
+
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(r.URL.Query().Get("countryCode")))
+	}))
+	t.Cleanup(server.Close)
+
+	return &Client{
+		httpClient:  server.Client(),
+		Environment: server.URL,
+		CountryCode: countryCode,
+		CacheTTL:    ttl,
+		cache:       newResponseCache(),
+	}, &requests
+}
+
+func TestClient_cache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, requests := newCacheTestClient(t, time.Hour, nil)
+
+	for i := 0; i < 3; i++ {
+		body, err := client.request(ctx, http.MethodGet, "/albums/1", nil)
+		if err != nil {
+			t.Fatalf("Client.request() error = %v", err)
+		}
+
+		if string(body) != countryCode {
+			t.Errorf("Client.request() = %s, want %s", body, countryCode)
+		}
+	}
+
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("Client.request() sent %d requests, want 1", got)
+	}
+
+	body, err := client.request(WithCountryCode(ctx, "DE"), http.MethodGet, "/albums/1", nil)
+	if err != nil {
+		t.Fatalf("Client.request() error = %v", err)
+	}
+
+	if string(body) != "DE" {
+		t.Errorf("Client.request() with a country override = %s, want DE", body)
+	}
+
+	if got := atomic.LoadInt32(requests); got != 2 {
+		t.Errorf("Client.request() sent %d requests, want a separate request for the override", got)
+	}
+
+	_, err = client.send(ctx, http.MethodDelete, "/albums/1", nil, requestOptions{})
+	if err != nil {
+		t.Fatalf("Client.send() error = %v", err)
+	}
+
+	_, err = client.request(ctx, http.MethodGet, "/albums/1", nil)
+	if err != nil {
+		t.Fatalf("Client.request() error = %v", err)
+	}
+
+	if got := atomic.LoadInt32(requests); got != 4 {
+		t.Errorf("Client.request() sent %d requests, want the cache cleared after a write", got)
+	}
+}
+
+func TestClient_cacheClearedOnPreconditionFailed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, requests := newCacheTestClient(t, time.Hour, nil)
+
+	_, err := client.request(ctx, http.MethodGet, "/playlists/1", nil)
+	if err != nil {
+		t.Fatalf("Client.request() error = %v", err)
+	}
+
+	_, err = client.editPlaylist(ctx, http.MethodPatch, "/playlists/1", "stale", nil)
+	if !errors.Is(err, ErrPlaylistModified) {
+		t.Fatalf("Client.editPlaylist() error = %v, want %v", err, ErrPlaylistModified)
+	}
+
+	_, err = client.request(ctx, http.MethodGet, "/playlists/1", nil)
+	if err != nil {
+		t.Fatalf("Client.request() error = %v", err)
+	}
+
+	if got := atomic.LoadInt32(requests); got != 3 {
+		t.Errorf("Client.request() sent %d requests, want the cache cleared after a 412", got)
+	}
+}
+
+func TestResponseCache_sweepsExpiredEntries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cache := newResponseCache()
+	fetch := func() (*apiResponse, error) { return &apiResponse{}, nil }
+
+	for _, key := range []string{"/albums/1", "/albums/2"} {
+		if _, err := cache.do(ctx, key, time.Nanosecond, fetch); err != nil {
+			t.Fatalf("responseCache.do() error = %v", err)
+		}
+	}
+
+	time.Sleep(time.Millisecond)
+
+	if _, err := cache.do(ctx, "/albums/3", time.Hour, fetch); err != nil {
+		t.Fatalf("responseCache.do() error = %v", err)
+	}
+
+	if len(cache.entries) != 1 {
+		t.Errorf("responseCache.entries has %d entries, want the expired entries removed", len(cache.entries))
+	}
+}
+
+func TestClient_cacheDeduplicates(t *testing.T) {
+	t.Parallel()
+
+	const callers = 5
+
+	release := make(chan struct{})
+	client, requests := newCacheTestClient(t, 0, release)
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, callers)
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := client.request(context.Background(), http.MethodGet, "/albums/1", nil)
+			errs <- err
+		}()
+	}
+
+	// Give every caller time to join the request in flight before the server responds.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Client.request() error = %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("Client.request() sent %d requests for concurrent callers, want 1", got)
+	}
+
+	_, err := client.request(context.Background(), http.MethodGet, "/albums/1", nil)
+	if err != nil {
+		t.Fatalf("Client.request() error = %v", err)
+	}
+
+	if got := atomic.LoadInt32(requests); got != 2 {
+		t.Errorf("Client.request() sent %d requests, want responses not cached without a CacheTTL", got)
+	}
+}
@@ -3,7 +3,6 @@ package gotidal
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +12,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
 )
 
@@ -20,10 +21,40 @@ const (
 	contentType = "application/vnd.tidal.v1+json"
 	environment = "https://openapi.tidal.com"
 	oauthURI    = "https://auth.tidal.com/v1/oauth2/token"
+
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	// defaultMaxRetries is the number of times NewClient retries a request that failed with a transient error.
+	defaultMaxRetries = 2
+
+	// retryBaseDelay is the delay before the first retry, doubling for each subsequent retry.
+	retryBaseDelay = 250 * time.Millisecond
+
+	// multipleIDsLimit is the maximum number of IDs sent in a single request to the endpoints that fetch multiple
+	// resources by ID.
+	multipleIDsLimit = 20
+
+	// defaultConcurrency is the number of requests bulk operations make at once unless told otherwise.
+	defaultConcurrency = 4
 )
 
 var ErrUnexpectedResponseCode = errors.New("returned an unexpected status code")
 
+// ResponseError is returned when the API responds with an unexpected status code. It matches
+// ErrUnexpectedResponseCode with errors.Is and keeps the response body so error details can be inspected.
+type ResponseError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%s: %d", ErrUnexpectedResponseCode, e.StatusCode)
+}
+
+func (e *ResponseError) Is(target error) bool {
+	return target == ErrUnexpectedResponseCode
+}
+
 // HTTPClient provides an interface to make HTTP requests.
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
@@ -31,11 +62,27 @@ type HTTPClient interface {
 
 // Client defines the parameters needed to create a TIDAL API client.
 type Client struct {
-	httpClient  HTTPClient
-	ContentType string
-	Environment string
-	Token       string
-	CountryCode string
+	httpClient    HTTPClient
+	ContentType   string
+	Environment   string
+	EnvironmentV2 string
+	Token         string
+	CountryCode   string
+
+	// Locale requests localized titles and descriptions. The API default is used when it is empty.
+	// Example: en_US, de_DE, ja_JP
+	Locale string
+
+	// MaxRetries is the number of times a request is retried after a network error, rate limiting or a temporary
+	// server error.
+	MaxRetries int
+
+	// CacheTTL is how long successful GET responses are reused. Zero disables caching. Concurrent identical GET
+	// requests are always sent once and share the response. Writes clear the cache.
+	CacheTTL time.Duration
+
+	tokenSource *refreshingTokenSource
+	cache       *responseCache
 }
 
 // PaginationParams defines the limit and offset for pagination functions.
@@ -44,10 +91,16 @@ type PaginationParams struct {
 	Offset int
 }
 
-// NewClient returns an API client based on a users credentials and location.
+// NewClient returns an API client based on a users credentials and location. The country code must be an ISO 3166-1
+// alpha-2 code.
 func NewClient(clientID string, clientSecret string, countryCode string) (*Client, error) {
 	ctx := context.Background()
 
+	code, err := ParseCountryCode(countryCode)
+	if err != nil {
+		return nil, err
+	}
+
 	httpClient := &http.Client{}
 
 	token, err := getAccessToken(ctx, httpClient, clientID, clientSecret)
@@ -56,81 +109,269 @@ func NewClient(clientID string, clientSecret string, countryCode string) (*Clien
 	}
 
 	return &Client{
-		httpClient:  httpClient,
-		ContentType: contentType,
-		Environment: environment,
-		Token:       token,
-		CountryCode: countryCode,
+		httpClient:    httpClient,
+		ContentType:   contentType,
+		Environment:   environment,
+		EnvironmentV2: environmentV2,
+		Token:         token,
+		CountryCode:   string(code),
+		MaxRetries:    defaultMaxRetries,
+		cache:         newResponseCache(),
 	}, nil
 }
 
 type authResponse struct {
-	AccessToken string `json:"access_token"`
-	TokenType   string `json:"token_type"`
-	ExpiresIn   int    `json:"expires_in"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	Scope        string `json:"scope"`
+	ExpiresIn    int    `json:"expires_in"`
 }
 
-func getAccessToken(ctx context.Context, httpClient HTTPClient, clientID string, clientSecret string) (string, error) {
-	basicAuth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", clientID, clientSecret)))
+func (r authResponse) userToken() *UserToken {
+	token := &UserToken{
+		AccessToken:  r.AccessToken,
+		RefreshToken: r.RefreshToken,
+		TokenType:    r.TokenType,
+		Scope:        r.Scope,
+	}
 
-	requestBody := []byte(`grant_type=client_credentials`)
+	if r.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+
+	return token
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oauthURI, bytes.NewBuffer(requestBody))
+func getAccessToken(ctx context.Context, httpClient HTTPClient, clientID string, clientSecret string) (string, error) {
+	token, err := getClientToken(ctx, httpClient, clientID, clientSecret)
 	if err != nil {
-		return "", fmt.Errorf("failed to create OAuth request: %w", err)
+		return "", err
 	}
 
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("Authorization", concat("Basic ", basicAuth))
+	return token.AccessToken, nil
+}
 
-	responseBody, err := processRequest(httpClient, req)
-	if err != nil {
-		return "", fmt.Errorf("failed to process the request: %w", err)
+func getClientToken(
+	ctx context.Context, httpClient HTTPClient, clientID string, clientSecret string,
+) (*UserToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+
+	config := OAuthConfig{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
 	}
 
-	var authResponse authResponse
+	return config.requestToken(ctx, httpClient, form)
+}
+
+// apiResponse holds the parts of a response needed by endpoints that read headers as well as the body.
+type apiResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
 
-	err = json.Unmarshal(responseBody, &authResponse)
+func processRequest(httpClient HTTPClient, req *http.Request) ([]byte, error) {
+	response, err := doRequest(httpClient, req)
 	if err != nil {
-		return "", fmt.Errorf("failed to unmarshal the OAuth response body: %w", err)
+		return nil, err
 	}
 
-	return authResponse.AccessToken, nil
+	return response.Body, nil
 }
 
-func processRequest(httpClient HTTPClient, req *http.Request) ([]byte, error) {
+func doRequest(httpClient HTTPClient, req *http.Request) (*apiResponse, error) {
 	response, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to process request: %w", err)
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusMultiStatus {
-		return nil, fmt.Errorf("%w: %d", ErrUnexpectedResponseCode, response.StatusCode)
-	}
-
 	responseBody, err := io.ReadAll(response.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read the OAuth response body: %w", err)
 	}
 
-	return responseBody, nil
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent, http.StatusMultiStatus:
+	default:
+		return nil, &ResponseError{StatusCode: response.StatusCode, Body: responseBody}
+	}
+
+	return &apiResponse{
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       responseBody,
+	}, nil
 }
 
-// nolint:unparam
+// request makes a read request and returns the response body. Writes use send, which supports request bodies and
+// headers.
+//
+// nolint:unparam // Reads are always GET requests.
 func (c *Client) request(ctx context.Context, method string, path string, params any) ([]byte, error) {
-	uri := fmt.Sprintf("%s%s?%s", c.Environment, path, toURLParams(params, c.CountryCode))
+	response, err := c.send(ctx, method, path, params, requestOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, method, uri, nil)
+// requestOptions defines the optional parts of a request.
+type requestOptions struct {
+	// Body is encoded as JSON when it is not nil.
+	Body any
+
+	// Header is added to the request. An Idempotency-Key set here is reused instead of generating one.
+	Header http.Header
+}
+
+// send makes a request with an optional JSON body and extra headers, and returns the full response so callers can
+// read the status code and headers such as the ETag.
+func (c *Client) send(
+	ctx context.Context, method string, path string, params any, options requestOptions,
+) (*apiResponse, error) {
+	query := toURLParams(params, c.countryCode(ctx))
+	if locale := c.locale(ctx); locale != "" {
+		query = concat(query, "&locale=", url.QueryEscape(locale))
+	}
+
+	uri := fmt.Sprintf("%s%s?%s", c.Environment, path, query)
+
+	return c.do(ctx, method, uri, c.ContentType, options)
+}
+
+// do sends a request to the API, retrying transient failures up to MaxRetries times.
+//
+// GET requests go through the response cache, keyed by URL so country and locale overrides are cached separately.
+// Any other successful request clears the cache.
+//
+// Writes are sent with an Idempotency-Key header that stays the same across retries. This lets the API recognise a
+// retried write and apply it only once, which matters for DELETE as well because playlist items are removed by index.
+func (c *Client) do(
+	ctx context.Context, method string, uri string, contentType string, options requestOptions,
+) (*apiResponse, error) {
+	err := c.checkCountryCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+
+	if options.Body != nil {
+		data, err := json.Marshal(options.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the request body for %s: %w", uri, err)
+		}
+
+		body = data
+	}
+
+	header := options.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	if method != http.MethodGet && header.Get(idempotencyKeyHeader) == "" {
+		key, err := randomString()
+		if err != nil {
+			return nil, err
+		}
+
+		header.Set(idempotencyKeyHeader, key)
+	}
+
+	if c.cache == nil {
+		return c.retry(ctx, method, uri, contentType, header, body)
+	}
+
+	if method == http.MethodGet && len(header) == 0 {
+		return c.cache.do(ctx, concat(contentType, " ", uri), c.CacheTTL, func() (*apiResponse, error) {
+			return c.retry(ctx, method, uri, contentType, header, body)
+		})
+	}
+
+	// A write that succeeded changes what later reads return, and one rejected with 412 Precondition Failed means
+	// the resource was changed elsewhere, so the cached copy the caller re-reads before retrying is stale either way.
+	response, err := c.retry(ctx, method, uri, contentType, header, body)
+	if method != http.MethodGet && (err == nil || isPreconditionFailed(err)) {
+		c.cache.clear()
+	}
+
+	return response, err
+}
+
+func (c *Client) retry(
+	ctx context.Context, method string, uri string, contentType string, header http.Header, body []byte,
+) (*apiResponse, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := c.attempt(ctx, method, uri, contentType, header, body)
+		if err == nil || attempt >= c.MaxRetries || !isRetryable(ctx, err) {
+			return response, err
+		}
+
+		timer := time.NewTimer(retryBaseDelay << attempt)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, fmt.Errorf("stopped retrying %s: %w", uri, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) attempt(
+	ctx context.Context, method string, uri string, contentType string, header http.Header, body []byte,
+) (*apiResponse, error) {
+	var requestBody io.Reader
+	if body != nil {
+		requestBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, uri, requestBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request for %s: %w", uri, err)
 	}
 
-	req.Header.Set("Content-Type", c.ContentType)
-	req.Header.Set("Authorization", concat("Bearer ", c.Token))
-	req.Header.Set("accept", c.ContentType)
+	authorization, err := c.authorization(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("accept", contentType)
+
+	return doRequest(c.httpClient, req)
+}
+
+// isRetryable reports whether a failed request is worth retrying: network errors, rate limiting and temporary server
+// errors.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var responseError *ResponseError
+	if !errors.As(err, &responseError) {
+		return true
+	}
 
-	return processRequest(c.httpClient, req)
+	switch responseError.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
 }
 
 func toURLParams(input interface{}, countryCode string) string {
@@ -172,6 +413,177 @@ func toURLParams(input interface{}, countryCode string) string {
 	return strings.Join(params, "&")
 }
 
+// chunkIDs splits a list of IDs into batches no larger than size so they can be passed to the endpoints that fetch
+// multiple resources at once.
+func chunkIDs(ids []string, size int) [][]string {
+	var chunks [][]string
+
+	for size < len(ids) {
+		ids, chunks = ids[size:], append(chunks, ids[:size])
+	}
+
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+
+	return chunks
+}
+
+// BatchIDs splits a list of IDs into batches the endpoints that fetch multiple resources at once accept in a single
+// request, for callers that want to handle the results of each batch as it arrives.
+func BatchIDs(ids []string) [][]string {
+	return chunkIDs(ids, multipleIDsLimit)
+}
+
+// resolveSimilar pages through the IDs returned by listIDs and fetches their resources in batches. The resources
+// are returned in the order of their IDs, and the IDs that were not returned, or whose batch failed, are returned as
+// unresolved. Only a failure to list the IDs, or the context ending, fails the whole call.
+func resolveSimilar[T any](
+	ctx context.Context,
+	listIDs func(ctx context.Context, params PaginationParams) ([]string, int, error),
+	fetch func(ctx context.Context, ids []string) ([]T, error),
+	resourceID func(resource T) string,
+) ([]T, []string, error) {
+	params := PaginationParams{
+		Limit:  paginationLimit,
+		Offset: 0,
+	}
+
+	var ids []string
+
+	for {
+		page, total, err := listIDs(ctx, params)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ids = append(ids, page...)
+		params.Offset += params.Limit
+
+		if len(page) == 0 || params.Offset >= total {
+			break
+		}
+	}
+
+	fetched := make(map[string]T, len(ids))
+
+	for _, batch := range chunkIDs(ids, multipleIDsLimit) {
+		results, err := fetch(ctx, batch)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, fmt.Errorf("stopped resolving the similar resources: %w", ctxErr)
+		}
+
+		// A failed batch leaves its IDs unresolved.
+		if err != nil {
+			continue
+		}
+
+		for _, resource := range results {
+			fetched[resourceID(resource)] = resource
+		}
+	}
+
+	var (
+		resources  []T
+		unresolved []string
+	)
+
+	for _, id := range ids {
+		resource, ok := fetched[id]
+		if !ok {
+			unresolved = append(unresolved, id)
+			continue
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, unresolved, nil
+}
+
+// fetchInBatches calls fetch with the IDs split into batches the multiple resource endpoints accept, and joins the
+// results in batch order. A list that fits in one batch, including an empty one, is passed through unchanged.
+func fetchInBatches[T any](
+	ctx context.Context, ids []string, fetch func(ctx context.Context, ids []string) ([]T, error),
+) ([]T, error) {
+	if len(ids) <= multipleIDsLimit {
+		return fetch(ctx, ids)
+	}
+
+	var results []T
+
+	for _, batch := range chunkIDs(ids, multipleIDsLimit) {
+		batchResults, err := fetch(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, batchResults...)
+	}
+
+	return results, nil
+}
+
+// RateLimit controls how bulk operations, such as availability checks, lookups and imports, fan out their requests.
+type RateLimit struct {
+	// Concurrency is the maximum number of requests in flight. Defaults to 4.
+	Concurrency int
+
+	// Interval is the minimum time between starting requests, to stay within the API rate limits. No limit is
+	// applied when it is zero.
+	Interval time.Duration
+}
+
+// runLimited calls fn for each of n jobs using at most concurrency goroutines, defaulting to 4, and starting jobs no
+// more often than interval. Once the context is done, the remaining jobs are called with the context error instead of
+// being started.
+func runLimited(
+	ctx context.Context, n int, concurrency int, interval time.Duration, fn func(i int, err error),
+) {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	var ticks <-chan time.Time
+
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		ticks = ticker.C
+	}
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency && w < n; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				fn(i, ctx.Err())
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		if ticks != nil && i > 0 && ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case <-ticks:
+			}
+		}
+
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+}
+
 // lowercaseFirstLetter converts the first letter of a string the lowercase to match the camel-casing of the TIDAL
 // API URL parameters.
 func lowercaseFirstLetter(str string) string {
@@ -2,10 +2,15 @@ package gotidal
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -29,6 +34,26 @@ func (c *mockHTTPClient) Do(req *http.Request) (*http.Response, error) { // noli
 	}, nil
 }
 
+// mockRoutedHTTPClient returns a payload file based on the path of the request, for functions that call more than one
+// endpoint. Unknown paths return a 404.
+type mockRoutedHTTPClient struct {
+	Routes map[string]string
+}
+
+func (c *mockRoutedHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	filePath, ok := c.Routes[req.URL.Path]
+	if !ok {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewBuffer(nil)),
+		}, nil
+	}
+
+	mock := &mockHTTPClient{FilePath: filePath, StatusCode: http.StatusOK}
+
+	return mock.Do(req)
+}
+
 func Test_lowercaseFirstLetter(t *testing.T) {
 	t.Parallel()
 
@@ -120,3 +145,226 @@ func Test_concat(t *testing.T) {
 		})
 	}
 }
+
+func Test_chunkIDs(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		ids  []string
+		size int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want [][]string
+	}{
+		{
+			"Empty",
+			args{
+				nil,
+				2,
+			},
+			nil,
+		},
+		{
+			"Exact multiple",
+			args{
+				[]string{"1", "2", "3", "4"},
+				2,
+			},
+			[][]string{{"1", "2"}, {"3", "4"}},
+		},
+		{
+			"Remainder",
+			args{
+				[]string{"1", "2", "3"},
+				2,
+			},
+			[][]string{{"1", "2"}, {"3"}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := chunkIDs(tt.args.ids, tt.args.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chunkIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_send(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		method         string
+		idempotencyKey string
+		contentType    string
+		body           string
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		body         any
+		statuses     []int
+		maxRetries   int
+		wantRequests int
+		wantErr      bool
+	}{
+		{
+			"Created",
+			http.MethodPost,
+			map[string]string{"title": "Road Trip"},
+			[]int{http.StatusCreated},
+			0,
+			1,
+			false,
+		},
+		{
+			"Accepted",
+			http.MethodPatch,
+			map[string]string{"title": "Road Trip"},
+			[]int{http.StatusAccepted},
+			0,
+			1,
+			false,
+		},
+		{
+			"No content",
+			http.MethodDelete,
+			nil,
+			[]int{http.StatusNoContent},
+			0,
+			1,
+			false,
+		},
+		{
+			"Write retried with the same idempotency key",
+			http.MethodPost,
+			map[string]string{"title": "Road Trip"},
+			[]int{http.StatusServiceUnavailable, http.StatusCreated},
+			1,
+			2,
+			false,
+		},
+		{
+			"Delete retried with the same idempotency key",
+			http.MethodDelete,
+			nil,
+			[]int{http.StatusBadGateway, http.StatusNoContent},
+			1,
+			2,
+			false,
+		},
+		{
+			"Retries exhausted",
+			http.MethodPut,
+			map[string]string{"title": "Road Trip"},
+			[]int{http.StatusBadGateway, http.StatusBadGateway},
+			1,
+			2,
+			true,
+		},
+		{
+			"Client errors are not retried",
+			http.MethodPost,
+			map[string]string{"title": ""},
+			[]int{http.StatusBadRequest, http.StatusCreated},
+			1,
+			1,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []request
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				requests = append(requests, request{
+					method:         r.Method,
+					idempotencyKey: r.Header.Get(idempotencyKeyHeader),
+					contentType:    r.Header.Get("Content-Type"),
+					body:           string(body),
+				})
+
+				w.WriteHeader(tt.statuses[len(requests)-1])
+			}))
+			defer server.Close()
+
+			client := &Client{
+				httpClient:  server.Client(),
+				Environment: server.URL,
+				ContentType: contentType,
+				MaxRetries:  tt.maxRetries,
+			}
+
+			response, err := client.send(context.Background(), tt.method, "/playlists", nil, requestOptions{Body: tt.body})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Client.send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(requests) != tt.wantRequests {
+				t.Fatalf("Client.send() made %d requests, want %d", len(requests), tt.wantRequests)
+			}
+
+			if !tt.wantErr && response.StatusCode != tt.statuses[len(tt.statuses)-1] {
+				t.Errorf("Client.send() StatusCode = %v, want %v", response.StatusCode, tt.statuses[len(tt.statuses)-1])
+			}
+
+			for _, req := range requests {
+				if req.method != tt.method || req.contentType != contentType {
+					t.Errorf("Client.send() request = %+v", req)
+				}
+
+				if tt.body != nil && !strings.HasPrefix(req.body, `{"title":`) {
+					t.Errorf("Client.send() body = %v", req.body)
+				}
+
+				if req.idempotencyKey == "" {
+					t.Errorf("Client.send() Idempotency-Key = %q for %s", req.idempotencyKey, tt.method)
+				}
+
+				if req.idempotencyKey != requests[0].idempotencyKey {
+					t.Errorf("Client.send() Idempotency-Key changed between retries")
+				}
+			}
+		})
+	}
+}
+
+func TestClient_requestV2NoContent(t *testing.T) {
+	t.Parallel()
+
+	var contentTypeHeader string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentTypeHeader = r.Header.Get("Content-Type")
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client(), EnvironmentV2: server.URL, CountryCode: countryCode}
+
+	doc, err := client.requestV2(context.Background(), http.MethodGet, "/playlists/abc", url.Values{})
+	if err != nil {
+		t.Fatalf("Client.requestV2() error = %v", err)
+	}
+
+	if len(doc.Data.Resources) != 0 {
+		t.Errorf("Client.requestV2() returned %d resources for an empty response", len(doc.Data.Resources))
+	}
+
+	if contentTypeHeader != jsonAPIContentType {
+		t.Errorf("Client.requestV2() Content-Type = %v, want %v", contentTypeHeader, jsonAPIContentType)
+	}
+}
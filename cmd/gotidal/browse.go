@@ -0,0 +1,530 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/tomjowitt/gotidal"
+)
+
+// Keys returned by readKey for the control characters and escape sequences the browser handles.
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyLeft      = "left"
+	keyRight     = "right"
+	keyEnter     = "enter"
+	keyBackspace = "backspace"
+	keyEscape    = "escape"
+	keyInterrupt = "interrupt"
+
+	browseHelp = "↑/↓ move  enter open  ← back  n/p page  / search  s similar  c copy ID  l copy link  q quit"
+
+	// escapeSequenceLength is the length of the arrow key escape sequences, such as ESC [ A.
+	escapeSequenceLength = 3
+)
+
+// catalog is the part of the client the browser uses, so it can be tested without the API.
+type catalog interface {
+	Search(ctx context.Context, params gotidal.SearchParams) (*gotidal.SearchResults, error)
+	GetAlbumsByArtist(ctx context.Context, id string, params gotidal.PaginationParams) ([]gotidal.Album, error)
+	GetAlbumTracks(ctx context.Context, id string) ([]gotidal.Track, error)
+	GetSimilarArtistsResolved(ctx context.Context, id string) (*gotidal.SimilarArtists, error)
+}
+
+// entry is a line in a list of results.
+type entry struct {
+	label string
+	link  gotidal.Link
+}
+
+// screen is a page of results. Screens whose results come from a paginated endpoint reload when paging, and the
+// others page through the entries they already hold.
+type screen struct {
+	title    string
+	entries  []entry
+	selected int
+	offset   int
+	more     bool
+	load     func(ctx context.Context, offset int) ([]entry, bool, error)
+}
+
+// browser is an interactive list of search results that drills from artists to albums to tracks.
+type browser struct {
+	catalog  catalog
+	keys     *bufio.Reader
+	out      io.Writer
+	pageSize int
+	raw      bool
+	screens  []*screen
+	status   string
+}
+
+func (a *app) browse(ctx context.Context, args []string) error {
+	state, err := makeRaw(os.Stdin)
+	if err == nil {
+		defer state.restore()
+	}
+
+	b := &browser{
+		catalog:  a.client,
+		keys:     bufio.NewReader(os.Stdin),
+		out:      a.stdout,
+		pageSize: a.options.limit,
+		raw:      err == nil,
+	}
+
+	if len(args) > 0 {
+		err = b.search(ctx, strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.run(ctx)
+}
+
+// run reads keys until the user quits or the context is done.
+func (b *browser) run(ctx context.Context) error {
+	for ctx.Err() == nil {
+		b.render()
+
+		key, err := b.readKey()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		b.status = ""
+
+		quit, err := b.handle(ctx, key)
+		if err != nil {
+			b.status = "error: " + err.Error()
+		}
+
+		if quit {
+			fmt.Fprint(b.out, "\x1b[H\x1b[2J")
+
+			return nil
+		}
+	}
+
+	return ctx.Err() // nolint:wrapcheck // Returned as is so the exit code reports the interruption.
+}
+
+func (b *browser) current() *screen {
+	if len(b.screens) == 0 {
+		return nil
+	}
+
+	return b.screens[len(b.screens)-1]
+}
+
+// handle acts on a key press and reports whether the browser should quit.
+func (b *browser) handle(ctx context.Context, key string) (bool, error) {
+	current := b.current()
+
+	switch key {
+	case "q", keyInterrupt:
+		return true, nil
+	case "/":
+		query, err := b.prompt("Search: ")
+		if err != nil || query == "" {
+			return false, err
+		}
+
+		return false, b.search(ctx, query)
+	}
+
+	if current == nil {
+		return false, nil
+	}
+
+	switch key {
+	case keyUp, "k":
+		current.selected = max(current.selected-1, 0)
+	case keyDown, "j":
+		current.selected = min(current.selected+1, max(len(b.visible(current))-1, 0))
+	case keyLeft, keyBackspace, "h":
+		if len(b.screens) > 1 {
+			b.screens = b.screens[:len(b.screens)-1]
+		}
+	case keyRight, keyEnter:
+		return false, b.open(ctx)
+	case "n":
+		return false, b.page(ctx, 1)
+	case "p":
+		return false, b.page(ctx, -1)
+	case "s":
+		return false, b.similar(ctx)
+	case "c", "l":
+		b.copy(key == "l")
+	}
+
+	return false, nil
+}
+
+// visible returns the entries on the current page of a screen.
+func (b *browser) visible(s *screen) []entry {
+	if s.load != nil {
+		return s.entries
+	}
+
+	start := min(s.offset, len(s.entries))
+
+	return s.entries[start:min(start+b.pageSize, len(s.entries))]
+}
+
+func (b *browser) selected() (entry, bool) {
+	current := b.current()
+	if current == nil {
+		return entry{}, false
+	}
+
+	visible := b.visible(current)
+	if current.selected >= len(visible) {
+		return entry{}, false
+	}
+
+	return visible[current.selected], true
+}
+
+// push loads the first page of a screen and shows it.
+func (b *browser) push(ctx context.Context, s *screen) error {
+	if s.load != nil {
+		entries, more, err := s.load(ctx, 0)
+		if err != nil {
+			return err
+		}
+
+		s.entries, s.more = entries, more
+	}
+
+	b.screens = append(b.screens, s)
+
+	return nil
+}
+
+func (b *browser) page(ctx context.Context, direction int) error {
+	current := b.current()
+	offset := current.offset + direction*b.pageSize
+
+	switch {
+	case offset < 0:
+		return nil
+	case current.load == nil:
+		if offset >= len(current.entries) {
+			return nil
+		}
+	case direction > 0 && !current.more:
+		return nil
+	default:
+		entries, more, err := current.load(ctx, offset)
+		if err != nil {
+			return err
+		}
+
+		// A full page may have been the last one, which only shows once the next page comes back empty.
+		if len(entries) == 0 {
+			current.more = false
+
+			return nil
+		}
+
+		current.entries, current.more = entries, more
+	}
+
+	current.offset, current.selected = offset, 0
+
+	return nil
+}
+
+// search replaces the screens with the results of a query, keeping them if the search fails.
+func (b *browser) search(ctx context.Context, query string) error {
+	previous := b.screens
+	b.screens = nil
+
+	err := b.push(ctx, &screen{
+		title: fmt.Sprintf("Search: %s", query),
+		load: func(ctx context.Context, offset int) ([]entry, bool, error) {
+			results, err := b.catalog.Search(ctx, gotidal.SearchParams{Query: query, Limit: b.pageSize, Offset: offset})
+			if err != nil {
+				return nil, false, err // nolint:wrapcheck // The client errors describe the endpoint.
+			}
+
+			var entries []entry
+
+			entries = append(entries, artistEntries(results.Artists)...)
+			entries = append(entries, albumEntries(results.Albums)...)
+			entries = append(entries, trackEntries(results.Tracks)...)
+
+			more := len(results.Artists) == b.pageSize || len(results.Albums) == b.pageSize ||
+				len(results.Tracks) == b.pageSize
+
+			return entries, more, nil
+		},
+	})
+	if err != nil {
+		b.screens = previous
+	}
+
+	return err
+}
+
+// open drills into the selected artist or album.
+func (b *browser) open(ctx context.Context) error {
+	selected, ok := b.selected()
+	if !ok {
+		return nil
+	}
+
+	switch selected.link.Type {
+	case gotidal.LinkTypeArtist:
+		return b.push(ctx, &screen{
+			title: "Albums by " + selected.label,
+			load: func(ctx context.Context, offset int) ([]entry, bool, error) {
+				albums, err := b.catalog.GetAlbumsByArtist(
+					ctx, selected.link.ID, gotidal.PaginationParams{Limit: b.pageSize, Offset: offset},
+				)
+				if err != nil {
+					return nil, false, err // nolint:wrapcheck // The client errors describe the endpoint.
+				}
+
+				return albumEntries(albums), len(albums) == b.pageSize, nil
+			},
+		})
+	case gotidal.LinkTypeAlbum:
+		tracks, err := b.catalog.GetAlbumTracks(ctx, selected.link.ID)
+		if err != nil {
+			return err // nolint:wrapcheck // The client errors describe the endpoint.
+		}
+
+		return b.push(ctx, &screen{title: "Tracks on " + selected.label, entries: trackEntries(tracks)})
+	default:
+		b.status = selected.link.URL()
+
+		return nil
+	}
+}
+
+// similar shows the artists similar to the selected artist.
+func (b *browser) similar(ctx context.Context) error {
+	selected, ok := b.selected()
+	if !ok || selected.link.Type != gotidal.LinkTypeArtist {
+		b.status = "select an artist to see similar artists"
+
+		return nil
+	}
+
+	similar, err := b.catalog.GetSimilarArtistsResolved(ctx, selected.link.ID)
+	if err != nil {
+		return err // nolint:wrapcheck // The client errors describe the endpoint.
+	}
+
+	return b.push(ctx, &screen{title: "Similar to " + selected.label, entries: artistEntries(similar.Artists)})
+}
+
+// copy copies the ID or share link of the selected entry to the clipboard with the OSC 52 escape sequence, which
+// most terminals support, and shows it in the status line for those that don't.
+func (b *browser) copy(link bool) {
+	selected, ok := b.selected()
+	if !ok {
+		return
+	}
+
+	text := selected.link.ID
+	if link {
+		text = selected.link.URL()
+	}
+
+	fmt.Fprintf(b.out, "\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+
+	b.status = "copied " + text
+}
+
+func (b *browser) render() {
+	var view strings.Builder
+
+	view.WriteString("\x1b[H\x1b[2J")
+
+	current := b.current()
+	if current == nil {
+		view.WriteString("Press / to search the TIDAL catalog.\n")
+	} else {
+		fmt.Fprintf(&view, "%s (page %d)\n\n", current.title, current.offset/max(b.pageSize, 1)+1)
+
+		for i, item := range b.visible(current) {
+			marker := "  "
+			if i == current.selected {
+				marker = "> "
+			}
+
+			fmt.Fprintf(&view, "%s%s\n", marker, item.label)
+		}
+	}
+
+	fmt.Fprintf(&view, "\n%s\n%s\n", b.status, browseHelp)
+
+	fmt.Fprint(b.out, view.String())
+}
+
+// readKey returns the next key press. Without raw mode, each line is read as a key so the browser can still be used
+// by typing a key and pressing enter.
+func (b *browser) readKey() (string, error) {
+	if !b.raw {
+		line, err := b.keys.ReadString('\n')
+		line = strings.TrimSpace(line)
+
+		if err != nil && line == "" {
+			return "", err // nolint:wrapcheck // io.EOF is compared by the caller.
+		}
+
+		if line == "" {
+			return keyEnter, nil
+		}
+
+		return line, nil
+	}
+
+	char, err := b.keys.ReadByte()
+	if err != nil {
+		return "", err // nolint:wrapcheck // io.EOF is compared by the caller.
+	}
+
+	return b.decodeKey(char)
+}
+
+func (b *browser) decodeKey(char byte) (string, error) {
+	switch char {
+	case '\r', '\n':
+		return keyEnter, nil
+	case 0x7f, '\b':
+		return keyBackspace, nil
+	case 0x03:
+		return keyInterrupt, nil
+	case 0x1b:
+		if b.keys.Buffered() < escapeSequenceLength-1 {
+			return keyEscape, nil
+		}
+
+		sequence := make([]byte, escapeSequenceLength-1)
+
+		_, err := io.ReadFull(b.keys, sequence)
+		if err != nil {
+			return "", err // nolint:wrapcheck // io.EOF is compared by the caller.
+		}
+
+		switch string(sequence) {
+		case "[A":
+			return keyUp, nil
+		case "[B":
+			return keyDown, nil
+		case "[C":
+			return keyRight, nil
+		case "[D":
+			return keyLeft, nil
+		default:
+			return keyEscape, nil
+		}
+	default:
+		return string(char), nil
+	}
+}
+
+// prompt reads a line of text, echoing it in raw mode. Escape cancels the prompt.
+func (b *browser) prompt(label string) (string, error) {
+	fmt.Fprint(b.out, label)
+
+	if !b.raw {
+		line, err := b.keys.ReadString('\n')
+		if err != nil && line == "" {
+			return "", err // nolint:wrapcheck // io.EOF is compared by the caller.
+		}
+
+		return strings.TrimSpace(line), nil
+	}
+
+	var text []rune
+
+	for {
+		char, _, err := b.keys.ReadRune()
+		if err != nil {
+			return "", err // nolint:wrapcheck // io.EOF is compared by the caller.
+		}
+
+		switch char {
+		case '\r', '\n':
+			return strings.TrimSpace(string(text)), nil
+		case 0x1b, 0x03:
+			return "", nil
+		case 0x7f, '\b':
+			if len(text) > 0 {
+				text = text[:len(text)-1]
+				fmt.Fprint(b.out, "\b \b")
+			}
+		default:
+			text = append(text, char)
+			fmt.Fprint(b.out, string(char))
+		}
+	}
+}
+
+func artistEntries(artists []gotidal.Artist) []entry {
+	entries := make([]entry, 0, len(artists))
+
+	for _, artist := range artists {
+		entries = append(entries, entry{
+			label: artist.Name,
+			link:  gotidal.Link{Type: gotidal.LinkTypeArtist, ID: artist.ID},
+		})
+	}
+
+	return entries
+}
+
+func albumEntries(albums []gotidal.Album) []entry {
+	entries := make([]entry, 0, len(albums))
+
+	for _, album := range albums {
+		label := album.Title
+		if len(album.Artists) > 0 {
+			label += " - " + album.Artists[0].Name
+		}
+
+		if year, _, _ := strings.Cut(album.ReleaseDate, "-"); year != "" {
+			label += " (" + year + ")"
+		}
+
+		entries = append(entries, entry{label: label, link: gotidal.Link{Type: gotidal.LinkTypeAlbum, ID: album.ID}})
+	}
+
+	return entries
+}
+
+func trackEntries(tracks []gotidal.Track) []entry {
+	entries := make([]entry, 0, len(tracks))
+
+	for _, track := range tracks {
+		label := track.Title
+		if track.TrackNumber > 0 {
+			label = fmt.Sprintf("%d. %s", track.TrackNumber, label)
+		}
+
+		if len(track.Artists) > 0 {
+			label += " - " + track.Artists[0].Name
+		}
+
+		entries = append(entries, entry{label: label, link: gotidal.Link{Type: gotidal.LinkTypeTrack, ID: track.ID}})
+	}
+
+	return entries
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tomjowitt/gotidal"
+)
+
+// fakeCatalog serves a small catalog of one artist with two albums and a similar artist.
+type fakeCatalog struct {
+	t *testing.T
+}
+
+func (f fakeCatalog) decode(data string, value any) {
+	f.t.Helper()
+
+	err := json.Unmarshal([]byte(data), value)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+func (f fakeCatalog) Search(_ context.Context, params gotidal.SearchParams) (*gotidal.SearchResults, error) {
+	var results gotidal.SearchResults
+
+	if params.Offset == 0 {
+		f.decode(`[{"resource":{"id":"11950","name":"New Order"}}]`, &results.Artists)
+	}
+
+	return &results, nil
+}
+
+func (f fakeCatalog) GetAlbumsByArtist(
+	_ context.Context, _ string, params gotidal.PaginationParams,
+) ([]gotidal.Album, error) {
+	var albums []gotidal.Album
+
+	f.decode(`[
+		{"resource":{"id":"1","title":"Movement","releaseDate":"1981-11-13","artists":[{"name":"New Order"}]}},
+		{"resource":{"id":"2","title":"Technique","releaseDate":"1989-01-30","artists":[{"name":"New Order"}]}}
+	]`, &albums)
+
+	return albums[min(params.Offset, len(albums)):min(params.Offset+params.Limit, len(albums))], nil
+}
+
+func (f fakeCatalog) GetAlbumTracks(_ context.Context, _ string) ([]gotidal.Track, error) {
+	var tracks []gotidal.Track
+
+	f.decode(`[{"resource":{"id":"10","title":"Fine Time","trackNumber":1,"artists":[{"name":"New Order"}]}}]`, &tracks)
+
+	return tracks, nil
+}
+
+func (f fakeCatalog) GetSimilarArtistsResolved(_ context.Context, _ string) (*gotidal.SimilarArtists, error) {
+	var similar gotidal.SimilarArtists
+
+	f.decode(`[{"resource":{"id":"4000","name":"Joy Division"}}]`, &similar.Artists)
+
+	return &similar, nil
+}
+
+func TestBrowser(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		keys       string
+		wantTitle  string
+		wantStatus string
+	}{
+		{"Search", "/new order\r", "Search: new order", ""},
+		{"Artist albums", "/new order\r\r", "Albums by New Order", ""},
+		{"Next page", "/new order\r\rn", "Albums by New Order (page 2)", ""},
+		{"No page after the last", "/new order\r\rnn", "Albums by New Order (page 2)", ""},
+		{"Album tracks", "/new order\r\rn\r", "Tracks on Technique - New Order (1989)", ""},
+		{"Back", "/new order\r\r\r\x1b[D", "Albums by New Order", ""},
+		{"Similar artists", "/new order\rs", "Similar to New Order", ""},
+		{"Copy link", "/new order\rs\rl", "Albums by Joy Division", "copied https://tidal.com/browse/album/1"},
+		{"Copy ID", "/new order\rc", "Search: new order", "copied 11950"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+
+			b := &browser{
+				catalog:  fakeCatalog{t: t},
+				keys:     bufio.NewReader(strings.NewReader(tt.keys)),
+				out:      &out,
+				pageSize: 1,
+				raw:      true,
+			}
+
+			err := b.run(context.Background())
+			if err != nil {
+				t.Fatalf("browser.run() error = %v", err)
+			}
+
+			views := strings.Split(out.String(), "\x1b[H\x1b[2J")
+			last := views[len(views)-1]
+
+			if !strings.Contains(last, tt.wantTitle) {
+				t.Errorf("browser.run() view = %q, want it to contain %q", last, tt.wantTitle)
+			}
+
+			if !strings.Contains(last, tt.wantStatus) {
+				t.Errorf("browser.run() view = %q, want the status %q", last, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestBrowserDecodeKey(t *testing.T) {
+	t.Parallel()
+
+	b := &browser{keys: bufio.NewReader(strings.NewReader("\x1b[A\x1b[C\x7fq\x03")), raw: true}
+
+	for _, want := range []string{keyUp, keyRight, keyBackspace, "q", keyInterrupt} {
+		got, err := b.readKey()
+		if err != nil {
+			t.Fatalf("browser.readKey() error = %v", err)
+		}
+
+		if got != want {
+			t.Errorf("browser.readKey() = %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/tomjowitt/gotidal"
+)
+
+const (
+	similarAlbums  = "album"
+	similarArtists = "artist"
+)
+
+// Default columns for each kind of resource, named by their JSON fields.
+const (
+	albumColumns  = "id,title,artists.name,releaseDate,numberOfTracks"
+	trackColumns  = "id,title,artists.name,album.title,isrc"
+	artistColumns = "id,name"
+	searchColumns = "type,id,title,name,artists.name"
+)
+
+type command struct {
+	name      string
+	arguments string
+	summary   string
+	minArgs   int
+	flags     func(flags *flag.FlagSet)
+	run       func(a *app, ctx context.Context, args []string) error
+}
+
+// commands returns the subcommands in the order they are listed in the usage. Each call returns new flag values, so
+// commands can be run more than once.
+func commands() []command {
+	cmds := []command{searchCommand()}
+	cmds = append(cmds, catalogCommands()...)
+	cmds = append(cmds, artistCommands()...)
+
+	return append(cmds,
+		discographyCommand(),
+		importCommand(),
+		command{
+			name:      "browse",
+			arguments: "[query]",
+			summary:   "Browse the catalog interactively, from artists to albums to tracks",
+			run:       (*app).browse,
+		},
+		command{
+			name:      "token",
+			arguments: "",
+			summary:   "Print an access token for the client credentials",
+			run:       (*app).token,
+		},
+	)
+}
+
+func searchCommand() command {
+	var searchType, popularity string
+
+	return command{
+		name:      "search",
+		arguments: "<query>",
+		summary:   "Search for albums, artists, tracks and videos",
+		minArgs:   1,
+		flags: func(flags *flag.FlagSet) {
+			flags.StringVar(&searchType, "type", "", "restrict results to ALBUMS, ARTISTS, TRACKS or VIDEOS")
+			flags.StringVar(&popularity, "popularity", "", "rank by WORLDWIDE or COUNTRY popularity")
+		},
+		run: func(a *app, ctx context.Context, args []string) error {
+			return a.search(ctx, strings.Join(args, " "), searchType, popularity)
+		},
+	}
+}
+
+// catalogCommands returns the commands that look up albums and tracks.
+func catalogCommands() []command {
+	return []command{
+		{
+			name:      "album",
+			arguments: "<id>...",
+			summary:   "Show albums by ID",
+			minArgs:   1,
+			run:       (*app).albums,
+		},
+		{
+			name:      "album-tracks",
+			arguments: "<id>",
+			summary:   "List the tracks of an album",
+			minArgs:   1,
+			run:       (*app).albumTracks,
+		},
+		{
+			name:      "track",
+			arguments: "<id>...",
+			summary:   "Show tracks by ID",
+			minArgs:   1,
+			run:       (*app).tracks,
+		},
+		{
+			name:      "isrc",
+			arguments: "<isrc>",
+			summary:   "Find the tracks with an ISRC",
+			minArgs:   1,
+			run:       (*app).isrc,
+		},
+		{
+			name:      "barcode",
+			arguments: "<upc-or-ean>",
+			summary:   "Find the albums with a barcode",
+			minArgs:   1,
+			run:       (*app).barcode,
+		},
+	}
+}
+
+// artistCommands returns the commands that look up artists and what is related to them.
+func artistCommands() []command {
+	return []command{
+		{
+			name:      "artist",
+			arguments: "<id>...",
+			summary:   "Show artists by ID",
+			minArgs:   1,
+			run:       (*app).artists,
+		},
+		{
+			name:      "artist-albums",
+			arguments: "<id>",
+			summary:   "List the albums of an artist",
+			minArgs:   1,
+			run:       (*app).artistAlbums,
+		},
+		{
+			name:      "similar",
+			arguments: "album|artist <id>",
+			summary:   "List albums or artists similar to an album or artist",
+			minArgs:   2, // nolint:gomnd // The kind of resource and its ID.
+			run:       (*app).similar,
+		},
+	}
+}
+
+func discographyCommand() command {
+	var export discographyFlags
+
+	return command{
+		name:      "discography",
+		arguments: "<artist-id>",
+		summary:   "Export every album and track of an artist as JSON, CSV or Markdown",
+		minArgs:   1,
+		flags:     export.register,
+		run: func(a *app, ctx context.Context, args []string) error {
+			return a.discography(ctx, args[0], export)
+		},
+	}
+}
+
+func importCommand() command {
+	var imports importFlags
+
+	return command{
+		name:      "import",
+		arguments: "<file.csv>",
+		summary:   "Match the tracks of a CSV export from another service to TIDAL tracks",
+		minArgs:   1,
+		flags:     imports.register,
+		run: func(a *app, ctx context.Context, args []string) error {
+			return a.importCSV(ctx, args[0], imports)
+		},
+	}
+}
+
+func (a *app) pagination() gotidal.PaginationParams {
+	return gotidal.PaginationParams{Limit: a.options.limit, Offset: a.options.offset}
+}
+
+func (a *app) search(ctx context.Context, query string, searchType string, popularity string) error {
+	results, err := a.client.Search(ctx, gotidal.SearchParams{
+		Query:      query,
+		Type:       strings.ToUpper(searchType),
+		Limit:      a.options.limit,
+		Offset:     a.options.offset,
+		Popularity: strings.ToUpper(popularity),
+	})
+	if err != nil {
+		return err // nolint:wrapcheck // The client errors describe the endpoint.
+	}
+
+	output, err := a.newPrinter(searchColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range []struct {
+		kind  string
+		value any
+	}{
+		{"album", results.Albums},
+		{"artist", results.Artists},
+		{"track", results.Tracks},
+		{"video", results.Videos},
+	} {
+		groupRecords, err := toRecords(group.value)
+		if err != nil {
+			return err
+		}
+
+		for _, rec := range groupRecords {
+			rec["type"] = group.kind
+		}
+
+		err = output.write(toItems(group.value), groupRecords)
+		if err != nil {
+			return err
+		}
+	}
+
+	return output.close()
+}
+
+func (a *app) albums(ctx context.Context, args []string) error {
+	if len(args) == 1 {
+		album, err := a.client.GetSingleAlbum(ctx, args[0])
+		if err != nil {
+			return err // nolint:wrapcheck // The client errors describe the endpoint.
+		}
+
+		return a.printValue(album, albumColumns)
+	}
+
+	output, err := a.newPrinter(albumColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range gotidal.BatchIDs(args) {
+		results, err := a.client.GetMultipleAlbums(ctx, batch)
+		if err != nil {
+			return err // nolint:wrapcheck // The client errors describe the endpoint.
+		}
+
+		err = writeValue(output, results)
+		if err != nil {
+			return err
+		}
+	}
+
+	return output.close()
+}
+
+func (a *app) albumTracks(ctx context.Context, args []string) error {
+	tracks, err := a.client.GetAlbumTracks(ctx, args[0])
+	if err != nil {
+		return err // nolint:wrapcheck // The client errors describe the endpoint.
+	}
+
+	return a.printValue(tracks, trackColumns)
+}
+
+func (a *app) tracks(ctx context.Context, args []string) error {
+	if len(args) == 1 {
+		track, err := a.client.GetSingleTrack(ctx, args[0])
+		if err != nil {
+			return err // nolint:wrapcheck // The client errors describe the endpoint.
+		}
+
+		return a.printValue(track, trackColumns)
+	}
+
+	output, err := a.newPrinter(trackColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range gotidal.BatchIDs(args) {
+		results, err := a.client.GetMultipleTracks(ctx, batch)
+		if err != nil {
+			return err // nolint:wrapcheck // The client errors describe the endpoint.
+		}
+
+		err = writeValue(output, results)
+		if err != nil {
+			return err
+		}
+	}
+
+	return output.close()
+}
+
+func (a *app) isrc(ctx context.Context, args []string) error {
+	tracks, err := a.client.GetTracksByISRC(ctx, args[0], a.pagination())
+	if err != nil {
+		return err // nolint:wrapcheck // The client errors describe the endpoint.
+	}
+
+	return a.printValue(tracks, trackColumns)
+}
+
+func (a *app) barcode(ctx context.Context, args []string) error {
+	albums, err := a.client.GetAlbumByBarcodeID(ctx, args[0])
+	if err != nil {
+		return err // nolint:wrapcheck // The client errors describe the endpoint.
+	}
+
+	return a.printValue(albums, albumColumns)
+}
+
+func (a *app) artists(ctx context.Context, args []string) error {
+	if len(args) == 1 {
+		artist, err := a.client.GetSingleArtist(ctx, args[0])
+		if err != nil {
+			return err // nolint:wrapcheck // The client errors describe the endpoint.
+		}
+
+		return a.printValue(artist, artistColumns)
+	}
+
+	output, err := a.newPrinter(artistColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range gotidal.BatchIDs(args) {
+		results, err := a.client.GetMultipleArtists(ctx, batch)
+		if err != nil {
+			return err // nolint:wrapcheck // The client errors describe the endpoint.
+		}
+
+		err = writeValue(output, results)
+		if err != nil {
+			return err
+		}
+	}
+
+	return output.close()
+}
+
+func (a *app) artistAlbums(ctx context.Context, args []string) error {
+	albums, err := a.client.GetAlbumsByArtist(ctx, args[0], a.pagination())
+	if err != nil {
+		return err // nolint:wrapcheck // The client errors describe the endpoint.
+	}
+
+	return a.printValue(albums, albumColumns)
+}
+
+func (a *app) similar(ctx context.Context, args []string) error {
+	switch args[0] {
+	case similarAlbums:
+		ids, err := a.client.GetSimilarAlbums(ctx, args[1], a.pagination())
+		if err != nil {
+			return err // nolint:wrapcheck // The client errors describe the endpoint.
+		}
+
+		if len(ids) == 0 {
+			return errNoResults
+		}
+
+		return a.albums(ctx, ids)
+	case similarArtists:
+		ids, err := a.client.GetSimilarArtists(ctx, args[1], a.pagination())
+		if err != nil {
+			return err // nolint:wrapcheck // The client errors describe the endpoint.
+		}
+
+		if len(ids) == 0 {
+			return errNoResults
+		}
+
+		return a.artists(ctx, ids)
+	default:
+		return fmt.Errorf("%w: similar needs album or artist, got %q", errUsage, args[0])
+	}
+}
+
+func (a *app) token(_ context.Context, _ []string) error {
+	_, err := fmt.Fprintln(a.stdout, a.client.Token)
+
+	return err // nolint:wrapcheck // Writing to stdout only fails if it has been closed.
+}
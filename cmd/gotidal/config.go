@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	defaultCountryCode = "US"
+	defaultLimit       = 10
+)
+
+// config is the optional JSON config file. Environment variables and flags take precedence over it.
+type config struct {
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+	CountryCode  string `json:"countryCode"`
+}
+
+// defaultConfigPath returns the config file in the user's config directory, such as ~/.config/gotidal/config.json.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "gotidal", "config.json")
+}
+
+// loadConfig reads the config file. A missing file is not an error as the credentials may be in the environment.
+func loadConfig(path string) (config, error) {
+	var cfg config
+
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read the config file: %w", err)
+	}
+
+	err = json.Unmarshal(data, &cfg)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal the config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tomjowitt/gotidal"
+)
+
+// Discography export formats.
+const (
+	formatJSON     = "json"
+	formatCSV      = "csv"
+	formatMarkdown = "markdown"
+
+	exportFilePerm = 0o644
+)
+
+type discographyFlags struct {
+	format     string
+	output     string
+	checkpoint string
+}
+
+func (f *discographyFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.format, "format", formatMarkdown, "export format: json, csv or markdown")
+	flags.StringVar(&f.output, "o", "", "file to write the export to instead of stdout")
+	flags.StringVar(&f.checkpoint, "checkpoint", "",
+		"file to save progress to, so an interrupted export resumes (defaults to the -o file with .checkpoint added)")
+}
+
+func (a *app) discography(ctx context.Context, artistID string, export discographyFlags) error {
+	write, err := discographyWriter(export.format)
+	if err != nil {
+		return err
+	}
+
+	checkpoint := export.checkpoint
+	if checkpoint == "" && export.output != "" {
+		checkpoint = export.output + ".checkpoint"
+	}
+
+	discography, err := a.client.GetDiscography(ctx, artistID, gotidal.DiscographyOptions{
+		CheckpointPath: checkpoint,
+		KeepCheckpoint: true,
+		Progress: func(done int, total int) {
+			fmt.Fprintf(a.stderr, "\rfetched %d of %d albums", done, total)
+
+			if done == total {
+				fmt.Fprintln(a.stderr)
+			}
+		},
+	})
+	if err != nil {
+		return err // nolint:wrapcheck // The client errors describe the endpoint.
+	}
+
+	if len(discography.Albums) == 0 {
+		return errNoResults
+	}
+
+	for _, album := range discography.Albums {
+		if album.Error != "" {
+			fmt.Fprintf(a.stderr, "skipped the tracks of album %s: %s\n", album.Album.ID, album.Error)
+		}
+	}
+
+	if export.output == "" {
+		err = write(discography, a.stdout)
+	} else {
+		err = writeExport(export.output, func(w io.Writer) error { return write(discography, w) })
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return removeCheckpoint(checkpoint)
+}
+
+// removeCheckpoint removes the checkpoint of an export once it has been written, and keeps it until then so a failed
+// write can be retried without fetching the discography again.
+func removeCheckpoint(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	err := os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove the checkpoint file: %w", err)
+	}
+
+	return nil
+}
+
+func discographyWriter(format string) (func(*gotidal.Discography, io.Writer) error, error) {
+	switch strings.ToLower(format) {
+	case formatJSON:
+		return (*gotidal.Discography).WriteJSON, nil
+	case formatCSV:
+		return (*gotidal.Discography).WriteCSV, nil
+	case formatMarkdown, "md":
+		return (*gotidal.Discography).WriteMarkdown, nil
+	default:
+		return nil, fmt.Errorf("%w: the format must be one of json, csv or markdown, got %q", errUsage, format)
+	}
+}
+
+// writeExport writes to a temporary file that replaces path once it is complete, so an interrupted write never
+// leaves a truncated export.
+func writeExport(path string, write func(w io.Writer) error) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create the export file: %w", err)
+	}
+
+	defer os.Remove(file.Name()) // nolint:errcheck // The file no longer exists once it has been renamed.
+
+	err = write(file)
+
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Chmod(file.Name(), exportFilePerm)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write the export file: %w", err)
+	}
+
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
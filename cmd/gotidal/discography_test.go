@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscographyWriter(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{formatJSON, formatCSV, formatMarkdown, "MD"} {
+		if _, err := discographyWriter(format); err != nil {
+			t.Errorf("discographyWriter(%q) error = %v", format, err)
+		}
+	}
+
+	if _, err := discographyWriter("xml"); !errors.Is(err, errUsage) {
+		t.Errorf("discographyWriter(xml) error = %v, want %v", err, errUsage)
+	}
+}
+
+func TestWriteExport(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "export.md")
+
+	err := os.WriteFile(path, []byte("previous"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failed := errors.New("interrupted")
+
+	err = writeExport(path, func(w io.Writer) error {
+		_, _ = io.WriteString(w, "partial")
+
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("writeExport() error = %v, want %v", err, failed)
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != "previous" {
+		t.Errorf("writeExport() replaced the file after failing: %q", data)
+	}
+
+	err = writeExport(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "complete")
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("writeExport() error = %v", err)
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != "complete" {
+		t.Errorf("writeExport() = %q, want complete", data)
+	}
+
+	if matches, _ := filepath.Glob(path + ".*.tmp"); len(matches) != 0 {
+		t.Errorf("writeExport() left temporary files behind: %v", matches)
+	}
+}
+
+func TestRemoveCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "export.md.checkpoint")
+
+	err := os.WriteFile(path, []byte("{}"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := removeCheckpoint(path); err != nil {
+			t.Errorf("removeCheckpoint() error = %v", err)
+		}
+	}
+
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("removeCheckpoint() left the file behind: %v", err)
+	}
+
+	if err := removeCheckpoint(""); err != nil {
+		t.Errorf("removeCheckpoint(\"\") error = %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tomjowitt/gotidal"
+)
+
+// Defaults of the import flags.
+const (
+	defaultImportConcurrency = 4
+	defaultMinConfidence     = 0.8
+)
+
+type importFlags struct {
+	output        string
+	unmatched     string
+	checkpoint    string
+	columns       string
+	delimiter     string
+	concurrency   int
+	minConfidence float64
+}
+
+func (f *importFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.output, "o", "", "file to write the results to (defaults to the input file with .results.csv)")
+	flags.StringVar(&f.unmatched, "unmatched", "", "file to also write the unmatched and failed rows to, for review")
+	flags.StringVar(&f.checkpoint, "checkpoint", "",
+		"file to save progress to, so an interrupted import resumes (defaults to the -o file with .checkpoint added)")
+	flags.StringVar(&f.columns, "map", "",
+		"header of each field, such as title=Song,artist=Performer; fields are title, artist, album, duration, "+
+			"duration-ms, isrc and explicit, and unmapped fields are detected from common headers")
+	flags.StringVar(&f.delimiter, "delimiter", ",", "field delimiter of the input, or tab")
+	flags.IntVar(&f.concurrency, "concurrency", defaultImportConcurrency, "number of rows matched at the same time")
+	flags.Float64Var(&f.minConfidence, "min-confidence", defaultMinConfidence,
+		"confidence between 0 and 1 a track needs to be accepted as the match")
+}
+
+func (a *app) importCSV(ctx context.Context, path string, flags importFlags) error {
+	columns, err := parseColumnMap(flags.columns)
+	if err != nil {
+		return err
+	}
+
+	comma, err := parseDelimiter(flags.delimiter)
+	if err != nil {
+		return err
+	}
+
+	output := flags.output
+	if output == "" {
+		output = strings.TrimSuffix(path, filepath.Ext(path)) + ".results.csv"
+	}
+
+	checkpoint := flags.checkpoint
+	if checkpoint == "" {
+		checkpoint = output + ".checkpoint"
+	}
+
+	input, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	defer input.Close()
+
+	summary, err := a.client.ImportCSV(ctx, input, output, gotidal.ImportOptions{
+		Columns:        columns,
+		Comma:          comma,
+		Match:          gotidal.MatchOptions{MinConfidence: flags.minConfidence},
+		RateLimit:      gotidal.RateLimit{Concurrency: flags.concurrency},
+		CheckpointPath: checkpoint,
+		UnmatchedPath:  flags.unmatched,
+		Progress: func(summary gotidal.ImportSummary) {
+			fmt.Fprintf(a.stderr, "\rimported %d rows", summary.Rows)
+		},
+	})
+	if summary != nil && summary.Rows > 0 {
+		fmt.Fprintln(a.stderr)
+	}
+
+	if err != nil {
+		return err // nolint:wrapcheck // The client errors describe the endpoint.
+	}
+
+	fmt.Fprintf(a.stdout, "%d rows: %d matched, %d unmatched, %d failed\nresults written to %s\n",
+		summary.Rows, summary.Matched, summary.Unmatched, summary.Failed, output)
+
+	return nil
+}
+
+// parseColumnMap parses the -map flag, a comma separated list of field=header pairs.
+func parseColumnMap(value string) (gotidal.CSVColumns, error) {
+	var columns gotidal.CSVColumns
+
+	for _, pair := range splitColumns(value) {
+		field, header, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(header) == "" {
+			return columns, fmt.Errorf("%w: -map needs field=header pairs, got %q", errUsage, pair)
+		}
+
+		header = strings.TrimSpace(header)
+
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "title":
+			columns.Title = header
+		case "artist":
+			columns.Artist = header
+		case "album":
+			columns.Album = header
+		case "duration":
+			columns.Duration = header
+		case "duration-ms":
+			columns.Duration = header
+			columns.DurationMilliseconds = true
+		case "isrc":
+			columns.ISRC = header
+		case "explicit":
+			columns.Explicit = header
+		default:
+			return columns, fmt.Errorf("%w: unknown -map field %q", errUsage, field)
+		}
+	}
+
+	return columns, nil
+}
+
+func parseDelimiter(value string) (rune, error) {
+	switch value {
+	case "tab", `\t`:
+		return '\t', nil
+	}
+
+	if utf8.RuneCountInString(value) != 1 {
+		return 0, fmt.Errorf("%w: -delimiter must be a single character or tab, got %q", errUsage, value)
+	}
+
+	delimiter, _ := utf8.DecodeRuneInString(value)
+
+	return delimiter, nil
+}
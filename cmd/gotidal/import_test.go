@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tomjowitt/gotidal"
+)
+
+func TestParseColumnMap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    gotidal.CSVColumns
+		wantErr error
+	}{
+		{"Empty", "", gotidal.CSVColumns{}, nil},
+		{
+			"Fields",
+			"title=Song, artist = Performer,duration-ms=Length,ISRC=Code",
+			gotidal.CSVColumns{
+				Title: "Song", Artist: "Performer", Duration: "Length", DurationMilliseconds: true, ISRC: "Code",
+			},
+			nil,
+		},
+		{"Missing header", "title", gotidal.CSVColumns{}, errUsage},
+		{"Unknown field", "genre=Genre", gotidal.CSVColumns{}, errUsage},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseColumnMap(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseColumnMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && got != tt.want {
+				t.Errorf("parseColumnMap() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDelimiter(t *testing.T) {
+	t.Parallel()
+
+	for value, want := range map[string]rune{",": ',', ";": ';', "tab": '\t', `\t`: '\t'} {
+		if got, err := parseDelimiter(value); err != nil || got != want {
+			t.Errorf("parseDelimiter(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+
+	if _, err := parseDelimiter("::"); !errors.Is(err, errUsage) {
+		t.Errorf("parseDelimiter(::) error = %v, want %v", err, errUsage)
+	}
+}
@@ -0,0 +1,264 @@
+// Command gotidal queries the TIDAL catalog from the command line.
+//
+// Usage:
+//
+//	gotidal [flags] <command> [arguments]
+//
+// Credentials are read from the TIDAL_CLIENT_ID and TIDAL_CLIENT_SECRET environment variables, falling back to the
+// config file. Run gotidal help for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/tomjowitt/gotidal"
+)
+
+// Exit codes returned by the command.
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitAuth        = 3
+	exitNotFound    = 4
+	exitRateLimited = 5
+	exitUnavailable = 6
+)
+
+var (
+	errUsage              = errors.New("invalid usage")
+	errUnknownCommand     = errors.New("unknown command")
+	errMissingCredentials = errors.New("TIDAL_CLIENT_ID and TIDAL_CLIENT_SECRET must be set in the environment or " +
+		"the config file")
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv)
+
+	stop()
+	os.Exit(code)
+}
+
+// options holds the global flags, which are accepted before or after the command name.
+type options struct {
+	config      string
+	countryCode string
+	limit       int
+	offset      int
+	output      string
+	columns     string
+	template    string
+}
+
+func (o *options) register(flags *flag.FlagSet) {
+	flags.StringVar(&o.config, "config", o.config, "path to the JSON config file")
+	flags.StringVar(&o.countryCode, "country", o.countryCode, "ISO 3166-1 alpha-2 country code of the catalog")
+	flags.IntVar(&o.limit, "limit", o.limit, "maximum number of results")
+	flags.IntVar(&o.offset, "offset", o.offset, "number of results to skip")
+	flags.StringVar(&o.output, "output", o.output, "output format: table, json, ndjson, csv or template")
+	flags.StringVar(&o.columns, "columns", o.columns, "comma separated JSON field names to print, such as id,artists.name")
+	flags.StringVar(&o.template, "template", o.template, "Go text/template executed for each result, such as {{.Title}}")
+}
+
+// app holds what a command needs to run, so tests can replace the environment and output.
+type app struct {
+	stdout  io.Writer
+	stderr  io.Writer
+	getenv  func(string) string
+	options options
+	client  *gotidal.Client
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer, getenv func(string) string) int {
+	cli := &app{
+		stdout:  stdout,
+		stderr:  stderr,
+		getenv:  getenv,
+		options: options{config: defaultConfigPath(), limit: defaultLimit, output: outputTable},
+	}
+
+	flags := flag.NewFlagSet("gotidal", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { cli.usage(flags) }
+	cli.options.register(flags)
+
+	err := flags.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	if err != nil {
+		return exitUsage
+	}
+
+	if flags.NArg() == 0 {
+		cli.usage(flags)
+
+		return exitUsage
+	}
+
+	err = cli.runCommand(ctx, flags.Arg(0), flags.Args()[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "gotidal: %v\n", err)
+	}
+
+	return exitCode(err)
+}
+
+func (a *app) usage(flags *flag.FlagSet) {
+	fmt.Fprintln(a.stderr, "Usage: gotidal [flags] <command> [arguments]")
+	fmt.Fprintln(a.stderr)
+	fmt.Fprintln(a.stderr, "Commands:")
+
+	for _, cmd := range commands() {
+		fmt.Fprintf(a.stderr, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+
+	fmt.Fprintln(a.stderr)
+	fmt.Fprintln(a.stderr, "Flags:")
+	flags.PrintDefaults()
+}
+
+func (a *app) runCommand(ctx context.Context, name string, args []string) error {
+	if name == "help" {
+		flags := flag.NewFlagSet("gotidal", flag.ContinueOnError)
+		flags.SetOutput(a.stderr)
+		a.options.register(flags)
+		a.usage(flags)
+
+		return nil
+	}
+
+	for _, cmd := range commands() {
+		if cmd.name != name {
+			continue
+		}
+
+		flags := flag.NewFlagSet("gotidal "+cmd.name, flag.ContinueOnError)
+		flags.SetOutput(a.stderr)
+		flags.Usage = func() {
+			fmt.Fprintf(a.stderr, "Usage: gotidal %s %s\n\n%s\n\nFlags:\n", cmd.name, cmd.arguments, cmd.summary)
+			flags.PrintDefaults()
+		}
+
+		a.options.register(flags)
+
+		if cmd.flags != nil {
+			cmd.flags(flags)
+		}
+
+		err := flags.Parse(args)
+		if err != nil {
+			return err // nolint:wrapcheck // The flag package has already printed the error.
+		}
+
+		if flags.NArg() < cmd.minArgs {
+			flags.Usage()
+
+			return fmt.Errorf("%w: %s needs %s", errUsage, cmd.name, cmd.arguments)
+		}
+
+		err = a.connect()
+		if err != nil {
+			return err
+		}
+
+		return cmd.run(a, ctx, flags.Args())
+	}
+
+	return fmt.Errorf("%w %q, run gotidal help for the list of commands", errUnknownCommand, name)
+}
+
+// connect creates the API client from the credentials in the environment or config file.
+func (a *app) connect() error {
+	config, err := loadConfig(a.options.config)
+	if err != nil {
+		return err
+	}
+
+	clientID := firstNonEmpty(a.getenv("TIDAL_CLIENT_ID"), config.ClientID)
+	clientSecret := firstNonEmpty(a.getenv("TIDAL_CLIENT_SECRET"), config.ClientSecret)
+	countryCode := firstNonEmpty(a.options.countryCode, a.getenv("TIDAL_COUNTRY_CODE"), config.CountryCode,
+		defaultCountryCode)
+
+	if clientID == "" || clientSecret == "" {
+		return errMissingCredentials
+	}
+
+	client, err := gotidal.NewClient(clientID, clientSecret, countryCode)
+	if err != nil {
+		return fmt.Errorf("failed to create the client: %w", err)
+	}
+
+	a.client = client
+
+	return nil
+}
+
+// exitCode maps an error to the exit code of the command, so scripts can tell usage mistakes, authentication
+// failures, missing resources and temporary failures apart.
+func exitCode(err error) int {
+	var (
+		responseError *gotidal.ResponseError
+		networkError  net.Error
+	)
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &responseError):
+		return responseExitCode(responseError.StatusCode)
+	case errors.Is(err, errMissingCredentials):
+		return exitAuth
+	case errors.Is(err, errUsage), errors.Is(err, errUnknownCommand), errors.Is(err, flag.ErrHelp),
+		errors.Is(err, gotidal.ErrMissingRequiredParameters), errors.Is(err, gotidal.ErrInvalidCountryCode),
+		errors.Is(err, gotidal.ErrInvalidISRC), errors.Is(err, gotidal.ErrInvalidBarcode),
+		errors.Is(err, gotidal.ErrUnrecognizedLink), errors.Is(err, errUnknownOutput),
+		errors.Is(err, gotidal.ErrUnknownColumn), errors.Is(err, gotidal.ErrMissingTitleColumn):
+		return exitUsage
+	case errors.Is(err, errNoResults):
+		return exitNotFound
+	case errors.As(err, &networkError) && !errors.Is(err, context.Canceled):
+		return exitUnavailable
+	default:
+		return exitError
+	}
+}
+
+func responseExitCode(statusCode int) int {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return exitAuth
+	case statusCode == http.StatusNotFound:
+		return exitNotFound
+	case statusCode == http.StatusTooManyRequests:
+		return exitRateLimited
+	case statusCode >= http.StatusInternalServerError:
+		return exitUnavailable
+	default:
+		return exitError
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tomjowitt/gotidal"
+)
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"Success", nil, exitOK},
+		{"Unauthorized", &gotidal.ResponseError{StatusCode: 401}, exitAuth},
+		{"Not found", fmt.Errorf("wrapped: %w", &gotidal.ResponseError{StatusCode: 404}), exitNotFound},
+		{"Rate limited", &gotidal.ResponseError{StatusCode: 429}, exitRateLimited},
+		{"Server error", &gotidal.ResponseError{StatusCode: 503}, exitUnavailable},
+		{"Bad request", &gotidal.ResponseError{StatusCode: 400}, exitError},
+		{"Missing credentials", errMissingCredentials, exitAuth},
+		{"Invalid country", fmt.Errorf("wrapped: %w", gotidal.ErrInvalidCountryCode), exitUsage},
+		{"Invalid ISRC", gotidal.ErrInvalidISRC, exitUsage},
+		{"No results", errNoResults, exitNotFound},
+		{"Other", errors.New("boom"), exitError},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	missingConfig := filepath.Join(t.TempDir(), "config.json")
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStderr string
+	}{
+		{"No command", []string{"-config", missingConfig}, exitUsage, "Usage: gotidal"},
+		{"Help", []string{"-config", missingConfig, "help"}, exitOK, "artist-albums"},
+		{"Unknown command", []string{"-config", missingConfig, "play"}, exitUsage, "unknown command"},
+		{"Missing arguments", []string{"-config", missingConfig, "album"}, exitUsage, "album needs <id>"},
+		{"Missing credentials", []string{"-config", missingConfig, "album", "51584178"}, exitAuth, "TIDAL_CLIENT_ID"},
+		{"Flags after the command", []string{"album", "-config", missingConfig, "1"}, exitAuth, "TIDAL_CLIENT_ID"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			getenv := func(string) string { return "" }
+
+			code := run(context.Background(), tt.args, &stdout, &stderr, getenv)
+			if code != tt.wantCode {
+				t.Errorf("run() = %v, want %v", code, tt.wantCode)
+			}
+
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("run() stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	err := os.WriteFile(path, []byte(`{"clientId":"id","clientSecret":"secret","countryCode":"AU"}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+
+	if cfg.ClientID != "id" || cfg.ClientSecret != "secret" || cfg.CountryCode != "AU" {
+		t.Errorf("loadConfig() = %+v", cfg)
+	}
+
+	_, err = loadConfig(filepath.Join(dir, "missing.json"))
+	if err != nil {
+		t.Errorf("loadConfig() error = %v for a missing file", err)
+	}
+}
+
+func TestCommands(t *testing.T) {
+	t.Parallel()
+
+	want := []string{
+		"search", "album", "album-tracks", "track", "isrc", "barcode", "artist", "artist-albums", "similar",
+		"discography", "import", "browse", "token",
+	}
+
+	cmds := commands()
+	if len(cmds) != len(want) {
+		t.Fatalf("commands() returned %d commands, want %d", len(cmds), len(want))
+	}
+
+	for i, cmd := range cmds {
+		if cmd.name != want[i] {
+			t.Errorf("commands()[%d] = %v, want %v", i, cmd.name, want[i])
+		}
+	}
+}
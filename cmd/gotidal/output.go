@@ -0,0 +1,437 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// Output formats selected with the -output flag.
+const (
+	outputTable    = "table"
+	outputJSON     = "json"
+	outputNDJSON   = "ndjson"
+	outputCSV      = "csv"
+	outputTemplate = "template"
+
+	tabMinWidth = 0
+	tabWidth    = 8
+	tabPadding  = 2
+)
+
+var (
+	errNoResults     = errors.New("no results")
+	errUnknownOutput = errors.New("the output format must be one of table, json, ndjson, csv or template")
+)
+
+// record is a resource decoded from its JSON form, so columns can be selected by their JSON field names.
+type record map[string]any
+
+// printer writes results in one of the output formats. Commands that fetch results in pages or batches call write
+// for each of them, so streaming formats such as NDJSON and CSV print results as they arrive.
+type printer interface {
+	// write prints a page of results. items are the API models, used by templates, and records are their JSON form.
+	write(items []any, records []record) error
+
+	// close prints anything buffered and returns errNoResults if nothing was written.
+	close() error
+}
+
+// newPrinter returns a printer for the -output flag. columns are the default JSON field names to print, replaced by
+// the -columns flag.
+func (a *app) newPrinter(columns string) (printer, error) {
+	format := a.options.output
+	if a.options.template != "" && (format == "" || format == outputTable) {
+		format = outputTemplate
+	}
+
+	selected := splitColumns(firstNonEmpty(a.options.columns, columns))
+
+	// JSON output keeps every field unless columns were selected explicitly.
+	var jsonColumns []string
+	if a.options.columns != "" {
+		jsonColumns = selected
+	}
+
+	switch format {
+	case "", outputTable:
+		return &tablePrinter{out: a.stdout, columns: selected}, nil
+	case outputJSON:
+		return &jsonPrinter{out: a.stdout, columns: jsonColumns, records: []any{}}, nil
+	case outputNDJSON:
+		return &ndjsonPrinter{encoder: json.NewEncoder(a.stdout), columns: jsonColumns}, nil
+	case outputCSV:
+		return &csvPrinter{writer: csv.NewWriter(a.stdout), columns: selected}, nil
+	case outputTemplate:
+		if a.options.template == "" {
+			return nil, fmt.Errorf("%w: the template output needs -template", errUsage)
+		}
+
+		tmpl, err := template.New("output").Option("missingkey=zero").Parse(a.options.template)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errUsage, err)
+		}
+
+		return &templatePrinter{out: a.stdout, template: tmpl}, nil
+	default:
+		return nil, fmt.Errorf("%w: %w, got %q", errUsage, errUnknownOutput, format)
+	}
+}
+
+// printValue prints a resource or a slice of resources.
+func (a *app) printValue(value any, columns string) error {
+	output, err := a.newPrinter(columns)
+	if err != nil {
+		return err
+	}
+
+	err = writeValue(output, value)
+	if err != nil {
+		return err
+	}
+
+	return output.close()
+}
+
+// writeValue writes a resource or a slice of resources to a printer.
+func writeValue(output printer, value any) error {
+	records, err := toRecords(value)
+	if err != nil {
+		return err
+	}
+
+	return output.write(toItems(value), records)
+}
+
+// toItems returns the elements of a slice, or the value itself if it is not a slice.
+func toItems(value any) []any {
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() != reflect.Slice {
+		return []any{value}
+	}
+
+	items := make([]any, reflected.Len())
+	for i := range items {
+		items[i] = reflected.Index(i).Interface()
+	}
+
+	return items
+}
+
+// toRecords converts a resource or a slice of resources to records. Resources wrapped in the API's resource envelope
+// are unwrapped.
+func toRecords(value any) ([]record, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the results: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var decoded any
+
+	err = decoder.Decode(&decoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the results: %w", err)
+	}
+
+	var items []any
+
+	switch typed := decoded.(type) {
+	case []any:
+		items = typed
+	case map[string]any:
+		items = []any{typed}
+	}
+
+	records := make([]record, 0, len(items))
+
+	for _, item := range items {
+		fields, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if resource, ok := fields["resource"].(map[string]any); ok && len(fields) == 1 {
+			fields = resource
+		}
+
+		records = append(records, fields)
+	}
+
+	return records, nil
+}
+
+// selectColumns returns a record with only the given columns, keyed by column name. All fields are kept when no
+// columns are given.
+func selectColumns(rec record, columns []string) any {
+	if len(columns) == 0 {
+		return rec
+	}
+
+	selected := make(map[string]any, len(columns))
+
+	for _, column := range columns {
+		if !strings.Contains(column, ".") {
+			selected[column] = rec[column]
+			continue
+		}
+
+		selected[column] = fieldValue(rec, column)
+	}
+
+	return selected
+}
+
+// fieldValue returns the value of a column, where nested fields are separated by dots. Fields of a list of objects,
+// such as artists.name, are joined with commas.
+func fieldValue(value any, column string) string {
+	name, rest, nested := strings.Cut(column, ".")
+
+	switch typed := value.(type) {
+	case record:
+		return fieldValue(map[string]any(typed), column)
+	case map[string]any:
+		if !nested {
+			return formatValue(typed[name])
+		}
+
+		return fieldValue(typed[name], rest)
+	case []any:
+		values := make([]string, 0, len(typed))
+
+		for _, item := range typed {
+			if text := fieldValue(item, column); text != "" {
+				values = append(values, text)
+			}
+		}
+
+		return strings.Join(values, ", ")
+	default:
+		return ""
+	}
+}
+
+func formatValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case []any, map[string]any:
+		data, err := json.Marshal(typed)
+		if err != nil {
+			return ""
+		}
+
+		return string(data)
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+func splitColumns(columns string) []string {
+	var names []string
+
+	for _, name := range strings.Split(columns, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// tablePrinter aligns the results in columns, so it waits for every result before printing.
+type tablePrinter struct {
+	out     io.Writer
+	columns []string
+	records []record
+}
+
+func (p *tablePrinter) write(_ []any, records []record) error {
+	p.records = append(p.records, records...)
+
+	return nil
+}
+
+func (p *tablePrinter) close() error {
+	if len(p.records) == 0 {
+		return errNoResults
+	}
+
+	writer := tabwriter.NewWriter(p.out, tabMinWidth, tabWidth, tabPadding, ' ', 0)
+
+	fmt.Fprintln(writer, strings.ToUpper(strings.Join(p.columns, "\t")))
+
+	for _, rec := range p.records {
+		values := make([]string, len(p.columns))
+		for i, column := range p.columns {
+			values[i] = strings.ReplaceAll(fieldValue(rec, column), "\t", " ")
+		}
+
+		fmt.Fprintln(writer, strings.Join(values, "\t"))
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write the results: %w", err)
+	}
+
+	return nil
+}
+
+// jsonPrinter prints the results as an indented JSON array.
+type jsonPrinter struct {
+	out     io.Writer
+	columns []string
+	records []any
+}
+
+func (p *jsonPrinter) write(_ []any, records []record) error {
+	for _, rec := range records {
+		p.records = append(p.records, selectColumns(rec, p.columns))
+	}
+
+	return nil
+}
+
+func (p *jsonPrinter) close() error {
+	data, err := json.MarshalIndent(p.records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the results: %w", err)
+	}
+
+	_, err = fmt.Fprintln(p.out, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to write the results: %w", err)
+	}
+
+	if len(p.records) == 0 {
+		return errNoResults
+	}
+
+	return nil
+}
+
+// ndjsonPrinter prints each result as a line of JSON as soon as it arrives.
+type ndjsonPrinter struct {
+	encoder *json.Encoder
+	columns []string
+	written bool
+}
+
+func (p *ndjsonPrinter) write(_ []any, records []record) error {
+	for _, rec := range records {
+		err := p.encoder.Encode(selectColumns(rec, p.columns))
+		if err != nil {
+			return fmt.Errorf("failed to write the results: %w", err)
+		}
+
+		p.written = true
+	}
+
+	return nil
+}
+
+func (p *ndjsonPrinter) close() error {
+	if !p.written {
+		return errNoResults
+	}
+
+	return nil
+}
+
+// csvPrinter prints a header row of column names, followed by a row for each result as it arrives.
+type csvPrinter struct {
+	writer  *csv.Writer
+	columns []string
+	written bool
+}
+
+func (p *csvPrinter) write(_ []any, records []record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	if !p.written {
+		p.written = true
+
+		err := p.writer.Write(p.columns)
+		if err != nil {
+			return fmt.Errorf("failed to write the results: %w", err)
+		}
+	}
+
+	for _, rec := range records {
+		values := make([]string, len(p.columns))
+		for i, column := range p.columns {
+			values[i] = fieldValue(rec, column)
+		}
+
+		err := p.writer.Write(values)
+		if err != nil {
+			return fmt.Errorf("failed to write the results: %w", err)
+		}
+	}
+
+	p.writer.Flush()
+
+	return p.writer.Error() // nolint:wrapcheck // The CSV writer errors describe the failed write.
+}
+
+func (p *csvPrinter) close() error {
+	if !p.written {
+		return errNoResults
+	}
+
+	return nil
+}
+
+// templatePrinter executes a text/template for each result, with the API model such as gotidal.Album as its data.
+// A newline is added after each result unless the template ends with one.
+type templatePrinter struct {
+	out      io.Writer
+	template *template.Template
+	written  bool
+}
+
+func (p *templatePrinter) write(items []any, _ []record) error {
+	for _, item := range items {
+		var buffer bytes.Buffer
+
+		err := p.template.Execute(&buffer, item)
+		if err != nil {
+			return fmt.Errorf("failed to execute the template: %w", err)
+		}
+
+		if !bytes.HasSuffix(buffer.Bytes(), []byte("\n")) {
+			buffer.WriteByte('\n')
+		}
+
+		_, err = p.out.Write(buffer.Bytes())
+		if err != nil {
+			return fmt.Errorf("failed to write the results: %w", err)
+		}
+
+		p.written = true
+	}
+
+	return nil
+}
+
+func (p *templatePrinter) close() error {
+	if !p.written {
+		return errNoResults
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/tomjowitt/gotidal"
+)
+
+const testAlbums = `[
+	{"resource":{"id":"51584178","title":"Power Corruption and Lies","numberOfTracks":8,
+		"artists":[{"id":"11950","name":"New Order"}]}},
+	{"resource":{"id":"77640617","title":"Low-Life, Remastered","numberOfTracks":8,
+		"artists":[{"id":"11950","name":"New Order"},{"id":"1","name":"Guest"}]}}
+]`
+
+func TestPrinters(t *testing.T) {
+	t.Parallel()
+
+	var albums []gotidal.Album
+
+	err := json.Unmarshal([]byte(testAlbums), &albums)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		options options
+		want    string
+		wantErr error
+	}{
+		{
+			"Table",
+			options{},
+			"ID        TITLE                      ARTISTS.NAME\n" +
+				"51584178  Power Corruption and Lies  New Order\n" +
+				"77640617  Low-Life, Remastered       New Order, Guest\n",
+			nil,
+		},
+		{
+			"CSV with selected columns",
+			options{output: outputCSV, columns: "title,artists.name,numberOfTracks"},
+			"title,artists.name,numberOfTracks\n" +
+				"Power Corruption and Lies,New Order,8\n" +
+				"\"Low-Life, Remastered\",\"New Order, Guest\",8\n",
+			nil,
+		},
+		{
+			"NDJSON with selected columns",
+			options{output: outputNDJSON, columns: "id,artists.name"},
+			`{"artists.name":"New Order","id":"51584178"}` + "\n" +
+				`{"artists.name":"New Order, Guest","id":"77640617"}` + "\n",
+			nil,
+		},
+		{
+			"JSON with selected columns",
+			options{output: outputJSON, columns: "id"},
+			"[\n  {\n    \"id\": \"51584178\"\n  },\n  {\n    \"id\": \"77640617\"\n  }\n]\n",
+			nil,
+		},
+		{
+			"Template over the models",
+			options{template: `{{.Title}} by {{(index .Artists 0).Name}}`},
+			"Power Corruption and Lies by New Order\nLow-Life, Remastered by New Order\n",
+			nil,
+		},
+		{
+			"Template without a template",
+			options{output: outputTemplate},
+			"",
+			errUsage,
+		},
+		{
+			"Unknown output",
+			options{output: "yaml"},
+			"",
+			errUnknownOutput,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout bytes.Buffer
+
+			cli := &app{stdout: &stdout, options: tt.options}
+
+			err := cli.printValue(albums, "id,title,artists.name")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("app.printValue() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if stdout.String() != tt.want {
+				t.Errorf("app.printValue() = %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintersNoResults(t *testing.T) {
+	t.Parallel()
+
+	for _, output := range []string{outputTable, outputJSON, outputNDJSON, outputCSV, outputTemplate} {
+		var stdout bytes.Buffer
+
+		cli := &app{stdout: &stdout, options: options{output: output, template: "{{.ID}}"}}
+
+		err := cli.printValue([]gotidal.Album{}, albumColumns)
+		if !errors.Is(err, errNoResults) {
+			t.Errorf("app.printValue() with %s output error = %v, want %v", output, err, errNoResults)
+		}
+	}
+}
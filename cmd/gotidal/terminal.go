@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+var errNotTerminal = errors.New("raw terminal mode is not supported")
+
+// terminalState restores the terminal settings changed by makeRaw.
+type terminalState struct {
+	fd      int
+	termios termios
+}
+
+// makeRaw switches the terminal to read single key presses without echoing them, keeping output processing so
+// newlines still return the cursor. It returns errNotTerminal when stdin is not a terminal, and the browser falls
+// back to reading whole lines.
+func makeRaw(file *os.File) (*terminalState, error) {
+	fd := int(file.Fd())
+
+	original, err := getTermios(fd)
+	if err != nil {
+		return nil, errNotTerminal
+	}
+
+	raw := *original
+	raw.Iflag &^= rawInputFlags
+	raw.Lflag &^= rawLocalFlags
+	raw.Cc[vmin] = 1
+	raw.Cc[vtime] = 0
+
+	err = setTermios(fd, &raw)
+	if err != nil {
+		return nil, errNotTerminal
+	}
+
+	return &terminalState{fd: fd, termios: *original}, nil
+}
+
+// restore returns the terminal to the settings it had before makeRaw.
+func (s *terminalState) restore() {
+	_ = setTermios(s.fd, &s.termios)
+}
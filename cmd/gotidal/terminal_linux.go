@@ -0,0 +1,43 @@
+package main
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+type termios = syscall.Termios
+
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+
+	rawInputFlags = syscall.ICRNL | syscall.IXON
+	rawLocalFlags = syscall.ICANON | syscall.ECHO | syscall.ISIG | syscall.IEXTEN
+
+	vmin  = syscall.VMIN
+	vtime = syscall.VTIME
+)
+
+func getTermios(fd int) (*termios, error) {
+	var value termios
+
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL, uintptr(fd), uintptr(ioctlGetTermios), uintptr(unsafe.Pointer(&value)),
+	)
+	if errno != 0 {
+		return nil, errno
+	}
+
+	return &value, nil
+}
+
+func setTermios(fd int, value *termios) error {
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL, uintptr(fd), uintptr(ioctlSetTermios), uintptr(unsafe.Pointer(value)),
+	)
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
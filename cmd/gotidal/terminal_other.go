@@ -0,0 +1,26 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package main
+
+// termios is a placeholder on platforms without termios, where the browser always reads whole lines.
+type termios struct {
+	Iflag uint32
+	Lflag uint32
+	Cc    [vtime + 1]uint8
+}
+
+const (
+	rawInputFlags = 0
+	rawLocalFlags = 0
+
+	vmin  = 0
+	vtime = 1
+)
+
+func getTermios(_ int) (*termios, error) {
+	return nil, errNotTerminal
+}
+
+func setTermios(_ int, _ *termios) error {
+	return errNotTerminal
+}
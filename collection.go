@@ -0,0 +1,221 @@
+package gotidal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	FavoriteTypeTracks  = "tracks"
+	FavoriteTypeAlbums  = "albums"
+	FavoriteTypeArtists = "artists"
+	FavoriteTypeVideos  = "videos"
+
+	FavoritesOrderDate   = "DATE"
+	FavoritesOrderName   = "NAME"
+	FavoritesOrderArtist = "ARTIST"
+
+	OrderDirectionAscending  = "ASC"
+	OrderDirectionDescending = "DESC"
+
+	// favoritesBatchLimit is the maximum number of IDs added to or removed from favorites in a single request.
+	favoritesBatchLimit = 50
+)
+
+var ErrUnknownFavoriteType = errors.New("the favorite type must be one of tracks, albums, artists or videos")
+
+// FavoritesParams defines the pagination and sort order of a favorites list.
+type FavoritesParams struct {
+	Limit  int
+	Offset int
+
+	// Order sorts the favorites. Defaults to the date they were added.
+	// Example: DATE, NAME, ARTIST
+	Order string
+
+	// OrderDirection defaults to descending.
+	// Example: ASC, DESC
+	OrderDirection string
+}
+
+// FavoriteTrack represents a track in a user's collection.
+type FavoriteTrack struct {
+	Track
+	AddedAt time.Time `json:"created"`
+}
+
+// FavoriteAlbum represents an album in a user's collection.
+type FavoriteAlbum struct {
+	Album
+	AddedAt time.Time `json:"created"`
+}
+
+// FavoriteArtist represents an artist in a user's collection.
+type FavoriteArtist struct {
+	Artist
+	AddedAt time.Time `json:"created"`
+}
+
+// FavoriteVideo represents a video in a user's collection.
+type FavoriteVideo struct {
+	Video
+	AddedAt time.Time `json:"created"`
+}
+
+// favoriteIDs lists the IDs of everything in a user's collection, keyed by the singular upper case resource type.
+type favoriteIDs map[string][]string
+
+func favoriteIDsKey(favoriteType string) (string, error) {
+	switch favoriteType {
+	case FavoriteTypeTracks, FavoriteTypeAlbums, FavoriteTypeArtists, FavoriteTypeVideos:
+		return strings.ToUpper(strings.TrimSuffix(favoriteType, "s")), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFavoriteType, favoriteType)
+	}
+}
+
+// GetFavoriteTracks returns a paginated list of the tracks in a user's collection.
+func (c *Client) GetFavoriteTracks(
+	ctx context.Context, userID string, params FavoritesParams,
+) ([]FavoriteTrack, error) {
+	return getFavorites[FavoriteTrack](ctx, c, userID, FavoriteTypeTracks, params)
+}
+
+// GetFavoriteAlbums returns a paginated list of the albums in a user's collection.
+func (c *Client) GetFavoriteAlbums(
+	ctx context.Context, userID string, params FavoritesParams,
+) ([]FavoriteAlbum, error) {
+	return getFavorites[FavoriteAlbum](ctx, c, userID, FavoriteTypeAlbums, params)
+}
+
+// GetFavoriteArtists returns a paginated list of the artists in a user's collection.
+func (c *Client) GetFavoriteArtists(
+	ctx context.Context, userID string, params FavoritesParams,
+) ([]FavoriteArtist, error) {
+	return getFavorites[FavoriteArtist](ctx, c, userID, FavoriteTypeArtists, params)
+}
+
+// GetFavoriteVideos returns a paginated list of the videos in a user's collection.
+func (c *Client) GetFavoriteVideos(
+	ctx context.Context, userID string, params FavoritesParams,
+) ([]FavoriteVideo, error) {
+	return getFavorites[FavoriteVideo](ctx, c, userID, FavoriteTypeVideos, params)
+}
+
+func getFavorites[T any](
+	ctx context.Context, c *Client, userID string, favoriteType string, params FavoritesParams,
+) ([]T, error) {
+	if userID == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	response, err := c.request(ctx, http.MethodGet, concat("/users/", userID, "/favorites/", favoriteType), params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the favorite %s endpoint: %w", favoriteType, err)
+	}
+
+	var results struct {
+		Data []T `json:"data"`
+	}
+
+	err = json.Unmarshal(response, &results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the favorite %s response body: %w", favoriteType, err)
+	}
+
+	return results.Data, nil
+}
+
+// AddFavorites adds tracks, albums, artists or videos to a user's collection, in batches of 50 IDs.
+func (c *Client) AddFavorites(ctx context.Context, userID string, favoriteType string, ids []string) error {
+	if userID == "" || len(ids) == 0 {
+		return ErrMissingRequiredParameters
+	}
+
+	if _, err := favoriteIDsKey(favoriteType); err != nil {
+		return err
+	}
+
+	type addFavoritesBody struct {
+		IDs []string `json:"ids"`
+	}
+
+	for _, batch := range chunkIDs(ids, favoritesBatchLimit) {
+		_, err := c.send(
+			ctx, http.MethodPost, concat("/users/", userID, "/favorites/", favoriteType), nil,
+			requestOptions{Body: addFavoritesBody{IDs: batch}},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to the favorite %s endpoint: %w", favoriteType, err)
+		}
+	}
+
+	return nil
+}
+
+// RemoveFavorites removes tracks, albums, artists or videos from a user's collection, in batches of 50 IDs.
+func (c *Client) RemoveFavorites(ctx context.Context, userID string, favoriteType string, ids []string) error {
+	if userID == "" || len(ids) == 0 {
+		return ErrMissingRequiredParameters
+	}
+
+	if _, err := favoriteIDsKey(favoriteType); err != nil {
+		return err
+	}
+
+	for _, batch := range chunkIDs(ids, favoritesBatchLimit) {
+		_, err := c.send(
+			ctx, http.MethodDelete,
+			concat("/users/", userID, "/favorites/", favoriteType, "/", strings.Join(batch, ",")), nil,
+			requestOptions{},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to connect to the favorite %s endpoint: %w", favoriteType, err)
+		}
+	}
+
+	return nil
+}
+
+// CheckFavorites reports which of the given IDs are in a user's collection.
+func (c *Client) CheckFavorites(
+	ctx context.Context, userID string, favoriteType string, ids []string,
+) (map[string]bool, error) {
+	if userID == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	key, err := favoriteIDsKey(favoriteType)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.request(ctx, http.MethodGet, concat("/users/", userID, "/favorites/ids"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the favorite IDs endpoint: %w", err)
+	}
+
+	var results favoriteIDs
+
+	err = json.Unmarshal(response, &results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the favorite IDs response body: %w", err)
+	}
+
+	favorites := make(map[string]bool, len(results[key]))
+	for _, id := range results[key] {
+		favorites[id] = true
+	}
+
+	checked := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		checked[id] = favorites[id]
+	}
+
+	return checked, nil
+}
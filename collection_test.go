@@ -0,0 +1,237 @@
+package gotidal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetFavoriteTracks(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		httpClient HTTPClient
+		userID     string
+	}
+
+	type expected struct {
+		IDs         []string
+		FirstISRC   string
+		FirstAdded  time.Time
+		FirstArtist string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		wantErr  bool
+	}{
+		{
+			"Missing user ID",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/favorite-tracks.json", StatusCode: http.StatusOK},
+				userID:     "",
+			},
+			expected{},
+			true,
+		},
+		{
+			"Token Error",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/401-token-error.json", StatusCode: http.StatusUnauthorized},
+				userID:     "123456",
+			},
+			expected{},
+			true,
+		},
+		{
+			"Favorite tracks parse correctly",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/favorite-tracks.json", StatusCode: http.StatusOK},
+				userID:     "123456",
+			},
+			expected{
+				IDs:         []string{"51584179", "251380837"},
+				FirstISRC:   "GBAAP1500379",
+				FirstAdded:  time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC),
+				FirstArtist: "New Order",
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{httpClient: tt.args.httpClient, CountryCode: countryCode}
+
+			params := FavoritesParams{Limit: 10, Order: FavoritesOrderDate, OrderDirection: OrderDirectionDescending}
+
+			tracks, err := client.GetFavoriteTracks(context.Background(), tt.args.userID, params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetFavoriteTracks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var ids []string
+			for _, track := range tracks {
+				ids = append(ids, track.ID)
+			}
+
+			if !reflect.DeepEqual(ids, tt.expected.IDs) {
+				t.Fatalf("Client.GetFavoriteTracks() IDs = %v, want %v", ids, tt.expected.IDs)
+			}
+
+			if tracks[0].ISRC != tt.expected.FirstISRC {
+				t.Errorf("Client.GetFavoriteTracks() ISRC = %v, want %v", tracks[0].ISRC, tt.expected.FirstISRC)
+			}
+
+			if !tracks[0].AddedAt.Equal(tt.expected.FirstAdded) {
+				t.Errorf("Client.GetFavoriteTracks() AddedAt = %v, want %v", tracks[0].AddedAt, tt.expected.FirstAdded)
+			}
+
+			if tracks[0].Artists[0].Name != tt.expected.FirstArtist {
+				t.Errorf("Client.GetFavoriteTracks() Artist = %v, want %v", tracks[0].Artists[0].Name, tt.expected.FirstArtist)
+			}
+		})
+	}
+}
+
+func TestCheckFavorites(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		favoriteType string
+		ids          []string
+		want         map[string]bool
+		wantErr      error
+	}{
+		{
+			"Tracks",
+			FavoriteTypeTracks,
+			[]string{"51584179", "1"},
+			map[string]bool{"51584179": true, "1": false},
+			nil,
+		},
+		{
+			"Albums",
+			FavoriteTypeAlbums,
+			[]string{"51584178"},
+			map[string]bool{"51584178": true},
+			nil,
+		},
+		{
+			"Unknown type",
+			"playlists",
+			[]string{"51584178"},
+			nil,
+			ErrUnknownFavoriteType,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{
+				httpClient: &mockRoutedHTTPClient{Routes: map[string]string{
+					"/users/123456/favorites/ids": "testdata/favorite-ids.json",
+				}},
+				CountryCode: countryCode,
+			}
+
+			got, err := client.CheckFavorites(context.Background(), "123456", tt.favoriteType, tt.ids)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Client.CheckFavorites() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.CheckFavorites() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddAndRemoveFavorites(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu        sync.Mutex
+		added     []string
+		removed   []string
+		addCalls  int
+		delCalls  int
+		addMethod string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/users/123456/favorites/albums":
+			var body struct {
+				IDs []string `json:"ids"`
+			}
+
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			added = append(added, body.IDs...)
+			addMethod = r.Method
+			addCalls++
+		case strings.HasPrefix(r.URL.Path, "/users/123456/favorites/albums/") && r.Method == http.MethodDelete:
+			removed = append(removed, strings.Split(strings.TrimPrefix(r.URL.Path, "/users/123456/favorites/albums/"), ",")...)
+			delCalls++
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client(), Environment: server.URL, CountryCode: countryCode}
+
+	ids := make([]string, 0, favoritesBatchLimit+1)
+	for i := 0; i <= favoritesBatchLimit; i++ {
+		ids = append(ids, strconv.Itoa(i))
+	}
+
+	err := client.AddFavorites(context.Background(), "123456", FavoriteTypeAlbums, ids)
+	if err != nil {
+		t.Fatalf("Client.AddFavorites() error = %v", err)
+	}
+
+	err = client.RemoveFavorites(context.Background(), "123456", FavoriteTypeAlbums, ids)
+	if err != nil {
+		t.Fatalf("Client.RemoveFavorites() error = %v", err)
+	}
+
+	if addMethod != http.MethodPost || addCalls != 2 || !reflect.DeepEqual(added, ids) {
+		t.Errorf("Client.AddFavorites() method = %v, calls = %v, added = %v", addMethod, addCalls, len(added))
+	}
+
+	if delCalls != 2 || !reflect.DeepEqual(removed, ids) {
+		t.Errorf("Client.RemoveFavorites() calls = %v, removed = %v", delCalls, len(removed))
+	}
+
+	err = client.AddFavorites(context.Background(), "123456", "playlists", ids)
+	if !errors.Is(err, ErrUnknownFavoriteType) {
+		t.Errorf("Client.AddFavorites() error = %v, want %v", err, ErrUnknownFavoriteType)
+	}
+}
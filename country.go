@@ -0,0 +1,62 @@
+package gotidal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// iso3166Alpha2 lists the officially assigned ISO 3166-1 alpha-2 codes, padded with spaces for lookups.
+	iso3166Alpha2 = " AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS" +
+		" BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER" +
+		" ES ET FI FJ FK FM FO FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE" +
+		" IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA" +
+		" MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM" +
+		" PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR" +
+		" SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU" +
+		" WF WS YE YT ZA ZM ZW "
+
+	// tidalMarkets lists the countries TIDAL is available in, padded with spaces for lookups.
+	tidalMarkets = " AD AE AR AT AU BA BE BG BR CA CH CL CO CY CZ DE DK DO EE ES FI FR GB GR HK HR HU IE IL IS IT JM" +
+		" KE LI LT LU LV MC ME MK MT MX MY NG NL NO NZ PE PL PR PT RO RS SE SG SI SK TH UG US ZA "
+)
+
+var ErrInvalidCountryCode = errors.New("the country code must be an ISO 3166-1 alpha-2 code such as US or GB")
+
+// CountryCode is an ISO 3166-1 alpha-2 country code. TIDAL uses it to select the market whose catalog and licensing
+// apply to a request.
+type CountryCode string
+
+// ParseCountryCode normalizes a country code to upper case and checks that it is an ISO 3166-1 alpha-2 code.
+func ParseCountryCode(code string) (CountryCode, error) {
+	countryCode := CountryCode(strings.ToUpper(strings.TrimSpace(code)))
+	if !countryCode.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCountryCode, code)
+	}
+
+	return countryCode, nil
+}
+
+// Valid reports whether the country code is an officially assigned ISO 3166-1 alpha-2 code.
+func (c CountryCode) Valid() bool {
+	return len(c) == 2 && strings.Contains(iso3166Alpha2, concat(" ", string(c), " "))
+}
+
+// IsMarket reports whether TIDAL is available in the country. The list of markets is built into the package, so
+// recently launched markets may be missing.
+func (c CountryCode) IsMarket() bool {
+	return len(c) == 2 && strings.Contains(tidalMarkets, concat(" ", string(c), " "))
+}
+
+// Markets returns the countries TIDAL is available in, in alphabetical order.
+func Markets() []CountryCode {
+	codes := strings.Fields(tidalMarkets)
+	markets := make([]CountryCode, 0, len(codes))
+
+	for _, code := range codes {
+		markets = append(markets, CountryCode(code))
+	}
+
+	return markets
+}
@@ -0,0 +1,138 @@
+package gotidal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseCountryCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		code    string
+		want    CountryCode
+		wantErr bool
+	}{
+		{"Upper case", "AU", "AU", false},
+		{"Normalized", " gb ", "GB", false},
+		{"Not a market", "JP", "JP", false},
+		{"Unassigned", "XX", "", true},
+		{"Alpha-3", "AUS", "", true},
+		{"Country name", "Australia", "", true},
+		{"Empty", "", "", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseCountryCode(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCountryCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, ErrInvalidCountryCode) {
+				t.Errorf("ParseCountryCode() error = %v, want %v", err, ErrInvalidCountryCode)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseCountryCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountryCode_IsMarket(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code CountryCode
+		want bool
+	}{
+		{"US", true},
+		{"AU", true},
+		{"NO", true},
+		{"JP", false},
+		{"CN", false},
+		{"us", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := tt.code.IsMarket(); got != tt.want {
+			t.Errorf("CountryCode(%q).IsMarket() = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestMarkets(t *testing.T) {
+	t.Parallel()
+
+	markets := Markets()
+	if len(markets) == 0 {
+		t.Fatal("Markets() returned no markets")
+	}
+
+	for i, market := range markets {
+		if !market.Valid() {
+			t.Errorf("Markets() includes %q, which is not an ISO 3166-1 alpha-2 code", market)
+		}
+
+		if i > 0 && markets[i-1] >= market {
+			t.Errorf("Markets() is not sorted at %q", market)
+		}
+	}
+
+	if got := len(strings.Fields(iso3166Alpha2)); got != 249 {
+		t.Errorf("ISO 3166-1 alpha-2 list has %d codes, want 249", got)
+	}
+}
+
+func TestNewClientInvalidCountryCode(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewClient("id", "secret", "Australia")
+	if !errors.Is(err, ErrInvalidCountryCode) {
+		t.Errorf("NewClient() error = %v, want %v", err, ErrInvalidCountryCode)
+	}
+}
+
+func TestNewUserClientInvalidCountryCode(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewUserClient(OAuthConfig{ClientID: "id"}, &UserToken{AccessToken: "token"}, "UK")
+	if !errors.Is(err, ErrInvalidCountryCode) {
+		t.Errorf("NewUserClient() error = %v, want %v", err, ErrInvalidCountryCode)
+	}
+
+	client, err := NewUserClient(OAuthConfig{ClientID: "id"}, &UserToken{AccessToken: "token"}, "gb")
+	if err != nil {
+		t.Fatalf("NewUserClient() error = %v", err)
+	}
+
+	if client.CountryCode != "GB" {
+		t.Errorf("NewUserClient() CountryCode = %v, want GB", client.CountryCode)
+	}
+}
+
+func TestClient_invalidCountryOverride(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{
+		httpClient:  &mockHTTPClient{FilePath: "testdata/single-album.json", StatusCode: http.StatusOK},
+		CountryCode: countryCode,
+	}
+
+	_, err := client.GetSingleAlbum(WithCountryCode(context.Background(), "UK"), "51584178")
+	if !errors.Is(err, ErrInvalidCountryCode) {
+		t.Errorf("Client.GetSingleAlbum() error = %v, want %v", err, ErrInvalidCountryCode)
+	}
+
+	_, err = client.GetSingleAlbum(WithCountryCode(context.Background(), "gb"), "51584178")
+	if err != nil {
+		t.Errorf("Client.GetSingleAlbum() error = %v for a lower case override", err)
+	}
+}
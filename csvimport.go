@@ -0,0 +1,628 @@
+package gotidal
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	ImportStatusMatched   = "matched"
+	ImportStatusUnmatched = "unmatched"
+	ImportStatusError     = "error"
+
+	defaultImportBatchSize = 100
+	importFilePerm         = 0o644
+)
+
+var (
+	ErrMissingTitleColumn = errors.New("the CSV file needs a title or an ISRC column")
+	ErrUnknownColumn      = errors.New("the CSV file has no such column")
+)
+
+// CSVColumns maps the fields of a track query to the header names of a CSV file. Columns left empty are detected from
+// common header names, such as "Track Name", "Artist Name(s)" and "Duration (ms)", as exported by other services.
+type CSVColumns struct {
+	Title    string
+	Artist   string
+	Album    string
+	Duration string
+	ISRC     string
+	Explicit string
+
+	// DurationMilliseconds reads plain numbers in the duration column as milliseconds rather than seconds. Durations
+	// such as 3:45 are always read as minutes and seconds. It is set when a detected duration header mentions ms.
+	DurationMilliseconds bool
+}
+
+// ImportOptions controls how a CSV file is imported.
+type ImportOptions struct {
+	Columns CSVColumns
+
+	// Comma is the field delimiter of the input, such as '\t' for a spreadsheet saved as tab separated values.
+	// Defaults to a comma.
+	Comma rune
+
+	Match MatchOptions
+
+	// RateLimit applies to matching the rows.
+	RateLimit
+
+	// BatchSize is the number of rows matched before their results are written and the checkpoint is saved.
+	// Defaults to 100.
+	BatchSize int
+
+	// CheckpointPath saves the progress of the import after each batch when it is set. An interrupted import called
+	// again with the same input, results file and checkpoint carries on after the last batch it wrote, and the file
+	// is removed once the import completes.
+	CheckpointPath string
+
+	// UnmatchedPath also writes the rows that were not matched or failed to this file, with the same columns as the
+	// results, for manual review. A resumed import must use the same path as the run that saved the checkpoint.
+	UnmatchedPath string
+
+	// Progress is called after each batch with the totals so far. It is never called concurrently.
+	Progress func(summary ImportSummary)
+}
+
+// ImportSummary counts the rows of an import by their status.
+type ImportSummary struct {
+	Rows      int `json:"rows"`
+	Matched   int `json:"matched"`
+	Unmatched int `json:"unmatched"`
+	Failed    int `json:"failed"`
+}
+
+// importCheckpoint is the progress of an import saved between runs. The sizes are the lengths of the output files
+// after the last batch, so rows written after it by an interrupted run are discarded.
+type importCheckpoint struct {
+	Header        []string      `json:"header"`
+	UnmatchedPath string        `json:"unmatchedPath,omitempty"`
+	Summary       ImportSummary `json:"summary"`
+	ResultsSize   int64         `json:"resultsSize"`
+	UnmatchedSize int64         `json:"unmatchedSize"`
+}
+
+// importResultColumns are added after the columns of the input in the results file.
+func importResultColumns() []string {
+	return []string{"tidalId", "tidalTitle", "tidalArtists", "tidalAlbum", "confidence", "status", "reasons"}
+}
+
+// ImportCSV matches every row of a CSV export from another service to a TIDAL track with MatchTrack and writes the
+// results to resultsPath. Each row of the results has the columns of the input followed by the matched track ID,
+// title, artists and album, the confidence, the status and the reasons for the score. Unmatched rows show the best
+// candidate, if any, and rows that could not be looked up show the error as their reason. Rows are padded or cut to
+// the number of columns in the header.
+func (c *Client) ImportCSV(
+	ctx context.Context, input io.Reader, resultsPath string, options ImportOptions,
+) (*ImportSummary, error) {
+	if resultsPath == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	imp, err := newCSVImport(input, options)
+	if err != nil {
+		return nil, err
+	}
+
+	err = imp.resume(resultsPath, options.UnmatchedPath)
+	if err != nil {
+		return nil, err
+	}
+	defer imp.close()
+
+	err = c.importBatches(ctx, imp, options)
+	if err != nil {
+		return &imp.checkpoint.Summary, err
+	}
+
+	if options.CheckpointPath != "" {
+		err = os.Remove(options.CheckpointPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return &imp.checkpoint.Summary, fmt.Errorf("failed to remove the checkpoint file: %w", err)
+		}
+	}
+
+	return &imp.checkpoint.Summary, nil
+}
+
+// csvImport is the state of an import: the input positioned at the next row to match, how its columns map to a track
+// query, the progress so far, and the output files.
+type csvImport struct {
+	reader     *csv.Reader
+	header     []string
+	mapping    csvMapping
+	checkpoint *importCheckpoint
+	results    *importFile
+	unmatched  *importFile
+}
+
+// newCSVImport reads the header of the input, maps its columns and loads the checkpoint of an earlier run.
+func newCSVImport(input io.Reader, options ImportOptions) (*csvImport, error) {
+	reader := csv.NewReader(input)
+	reader.FieldsPerRecord = -1
+
+	if options.Comma != 0 {
+		reader.Comma = options.Comma
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CSV header: %w", err)
+	}
+
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	mapping, err := newCSVMapping(header, options.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint, err := loadImportCheckpoint(options.CheckpointPath, header, options.UnmatchedPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csvImport{reader: reader, header: header, mapping: mapping, checkpoint: checkpoint}, nil
+}
+
+// resume opens the output files at their size at the checkpoint and skips the input rows that were already imported.
+func (imp *csvImport) resume(resultsPath string, unmatchedPath string) error {
+	outputHeader := append(slices.Clone(imp.header), importResultColumns()...)
+
+	var err error
+
+	imp.results, err = openImportFile(resultsPath, imp.checkpoint.ResultsSize, outputHeader)
+	if err != nil {
+		return err
+	}
+
+	if unmatchedPath != "" {
+		imp.unmatched, err = openImportFile(unmatchedPath, imp.checkpoint.UnmatchedSize, outputHeader)
+		if err != nil {
+			imp.close()
+
+			return err
+		}
+	}
+
+	for i := 0; i < imp.checkpoint.Summary.Rows; i++ {
+		_, err = imp.reader.Read()
+		if err != nil {
+			imp.close()
+
+			return fmt.Errorf("failed to skip the imported rows: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// importBatches matches the remaining rows a batch at a time, writing the results and saving the checkpoint after
+// each batch.
+func (c *Client) importBatches(ctx context.Context, imp *csvImport, options ImportOptions) error {
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+
+	for {
+		batch, err := readImportBatch(imp.reader, batchSize, len(imp.header))
+		if err != nil || len(batch) == 0 {
+			return err
+		}
+
+		matches, errs := c.matchImportBatch(ctx, batch, imp.mapping, options)
+
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("CSV import stopped: %w", err)
+		}
+
+		err = imp.writeBatch(batch, matches, errs)
+		if err == nil {
+			err = saveImportCheckpoint(options.CheckpointPath, imp.checkpoint)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if options.Progress != nil {
+			options.Progress(imp.checkpoint.Summary)
+		}
+	}
+}
+
+// writeBatch writes the results of a batch and records the sizes of the output files in the checkpoint.
+func (imp *csvImport) writeBatch(batch [][]string, matches []*TrackMatch, errs []error) error {
+	for i, record := range batch {
+		row, status := importResultRow(record, matches[i], errs[i])
+
+		err := imp.results.write(row)
+		if err == nil && imp.unmatched != nil && status != ImportStatusMatched {
+			err = imp.unmatched.write(row)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		imp.checkpoint.Summary.add(status)
+	}
+
+	var err error
+
+	imp.checkpoint.ResultsSize, err = imp.results.sync()
+	if err == nil && imp.unmatched != nil {
+		imp.checkpoint.UnmatchedSize, err = imp.unmatched.sync()
+	}
+
+	return err
+}
+
+func (imp *csvImport) close() {
+	if imp.results != nil {
+		imp.results.close()
+	}
+
+	if imp.unmatched != nil {
+		imp.unmatched.close()
+	}
+}
+
+func (s *ImportSummary) add(status string) {
+	s.Rows++
+
+	switch status {
+	case ImportStatusMatched:
+		s.Matched++
+	case ImportStatusUnmatched:
+		s.Unmatched++
+	default:
+		s.Failed++
+	}
+}
+
+// readImportBatch reads up to size records, each padded or cut to the number of columns.
+func readImportBatch(reader *csv.Reader, size int, columns int) ([][]string, error) {
+	var batch [][]string
+
+	for len(batch) < size {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CSV file: %w", err)
+		}
+
+		if len(record) < columns {
+			record = append(record, make([]string, columns-len(record))...)
+		}
+
+		batch = append(batch, record[:columns])
+	}
+
+	return batch, nil
+}
+
+func (c *Client) matchImportBatch(
+	ctx context.Context, batch [][]string, mapping csvMapping, options ImportOptions,
+) ([]*TrackMatch, []error) {
+	matches := make([]*TrackMatch, len(batch))
+	errs := make([]error, len(batch))
+
+	runLimited(ctx, len(batch), options.Concurrency, options.Interval, func(i int, err error) {
+		if err == nil {
+			matches[i], err = c.MatchTrack(ctx, mapping.query(batch[i]), options.Match)
+		}
+
+		errs[i] = err
+	})
+
+	return matches, errs
+}
+
+// importResultRow returns the input record followed by the result columns, and the status of the row.
+func importResultRow(record []string, match *TrackMatch, err error) ([]string, string) {
+	row := slices.Clone(record)
+
+	if err != nil {
+		return append(row, "", "", "", "", "", ImportStatusError, err.Error()), ImportStatusError
+	}
+
+	status := ImportStatusMatched
+
+	candidate := match.Match
+	if candidate == nil {
+		status = ImportStatusUnmatched
+
+		if len(match.Candidates) > 0 {
+			candidate = &match.Candidates[0]
+		}
+	}
+
+	if candidate == nil {
+		return append(row, "", "", "", "", "", status, "no candidates found"), status
+	}
+
+	return append(
+		row, candidate.Track.ID, trackTitle(candidate.Track), artistNames(candidate.Track.Artists),
+		candidate.Track.Album.Title, strconv.FormatFloat(candidate.Confidence, 'f', -1, 64), status,
+		strings.Join(candidate.Reasons, "; "),
+	), status
+}
+
+// csvMapping holds the index of each column of a track query, or -1 if the CSV file does not have it.
+type csvMapping struct {
+	title, artist, album, duration, isrc, explicit int
+	durationMilliseconds                           bool
+}
+
+func newCSVMapping(header []string, columns CSVColumns) (csvMapping, error) {
+	mapping := csvMapping{durationMilliseconds: columns.DurationMilliseconds}
+
+	fields := []struct {
+		index      *int
+		name       string
+		candidates []string
+	}{
+		{&mapping.title, columns.Title, []string{"title", "track name", "track title", "track", "song", "song name", "name"}},
+		{&mapping.artist, columns.Artist, []string{
+			"artist", "artists", "artist name", "artist names", "artist name s", "artist s", "creator",
+		}},
+		{&mapping.album, columns.Album, []string{"album", "album name", "album title", "release"}},
+		{&mapping.duration, columns.Duration, []string{
+			"duration", "duration ms", "track duration ms", "duration s", "length", "time",
+		}},
+		{&mapping.isrc, columns.ISRC, []string{"isrc"}},
+		{&mapping.explicit, columns.Explicit, []string{"explicit"}},
+	}
+
+	for _, field := range fields {
+		*field.index = -1
+
+		if field.name != "" {
+			*field.index = slices.Index(header, field.name)
+			if *field.index < 0 {
+				return mapping, fmt.Errorf("%w: %q", ErrUnknownColumn, field.name)
+			}
+
+			continue
+		}
+
+		*field.index = detectColumn(header, field.candidates)
+	}
+
+	if mapping.title < 0 && mapping.isrc < 0 {
+		return mapping, ErrMissingTitleColumn
+	}
+
+	if columns.Duration == "" && mapping.duration >= 0 {
+		words := strings.Fields(normalizeMatchText(header[mapping.duration]))
+		mapping.durationMilliseconds = mapping.durationMilliseconds || slices.Contains(words, "ms")
+	}
+
+	return mapping, nil
+}
+
+// detectColumn returns the index of the first header that matches a candidate, trying the candidates in order and
+// ignoring case and punctuation.
+func detectColumn(header []string, candidates []string) int {
+	for _, candidate := range candidates {
+		for i, name := range header {
+			if normalizeMatchText(name) == candidate {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func (m csvMapping) query(record []string) TrackQuery {
+	field := func(index int) string {
+		if index < 0 {
+			return ""
+		}
+
+		return strings.TrimSpace(record[index])
+	}
+
+	return TrackQuery{
+		Title:    field(m.title),
+		Artist:   field(m.artist),
+		Album:    field(m.album),
+		Duration: parseImportDuration(field(m.duration), m.durationMilliseconds),
+		ISRC:     field(m.isrc),
+		Explicit: parseImportExplicit(field(m.explicit)),
+	}
+}
+
+// parseImportDuration reads a duration such as 3:45 or 1:02:03, or a number of seconds or milliseconds. Durations
+// that cannot be read are unknown.
+func parseImportDuration(value string, milliseconds bool) int {
+	if value == "" {
+		return 0
+	}
+
+	if strings.Contains(value, ":") {
+		seconds := 0
+
+		for _, part := range strings.Split(value, ":") {
+			number, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || number < 0 {
+				return 0
+			}
+
+			seconds = seconds*secondsPerMinute + number
+		}
+
+		return seconds
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0
+	}
+
+	if milliseconds {
+		number /= millisecondsPerSecond
+	}
+
+	return int(math.Round(number))
+}
+
+func parseImportExplicit(value string) *bool {
+	var explicit bool
+
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1", "explicit":
+		explicit = true
+	case "false", "no", "n", "0", "clean":
+		explicit = false
+	default:
+		return nil
+	}
+
+	return &explicit
+}
+
+func loadImportCheckpoint(path string, header []string, unmatchedPath string) (*importCheckpoint, error) {
+	checkpoint := &importCheckpoint{Header: header, UnmatchedPath: unmatchedPath}
+
+	if path == "" {
+		return checkpoint, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return checkpoint, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the checkpoint file: %w", err)
+	}
+
+	// The saved checkpoint is read into an empty value, so fields it leaves out are not taken from this run.
+	saved := &importCheckpoint{}
+
+	err = json.Unmarshal(data, saved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the checkpoint file: %w", err)
+	}
+
+	if !slices.Equal(saved.Header, header) {
+		return nil, fmt.Errorf("%w: %s has different columns", ErrCheckpointMismatch, path)
+	}
+
+	if saved.UnmatchedPath != unmatchedPath {
+		return nil, fmt.Errorf(
+			"%w: %s was saved with the unmatched rows file %q", ErrCheckpointMismatch, path, saved.UnmatchedPath,
+		)
+	}
+
+	return saved, nil
+}
+
+func saveImportCheckpoint(path string, checkpoint *importCheckpoint) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the checkpoint: %w", err)
+	}
+
+	return writeFileAtomic(path, data, checkpointFilePerm)
+}
+
+// importFile is an output file of an import that is appended to after each batch.
+type importFile struct {
+	file   *os.File
+	writer *csv.Writer
+}
+
+// openImportFile opens an output file. A new import, with a size of zero, replaces the file and writes the header.
+// A resumed import truncates the file to its size at the checkpoint and appends to it.
+func openImportFile(path string, size int64, header []string) (*importFile, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, importFilePerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	output := &importFile{file: file, writer: csv.NewWriter(file)}
+
+	info, err := file.Stat()
+	if err == nil && info.Size() < size {
+		output.close()
+
+		return nil, fmt.Errorf("%w: %s is shorter than when the checkpoint was saved", ErrCheckpointMismatch, path)
+	}
+
+	if err == nil {
+		err = file.Truncate(size)
+	}
+
+	if err == nil {
+		_, err = file.Seek(size, io.SeekStart)
+	}
+
+	if err == nil && size == 0 {
+		err = output.write(header)
+	}
+
+	if err != nil {
+		output.close()
+
+		return nil, fmt.Errorf("failed to prepare %s: %w", path, err)
+	}
+
+	return output, nil
+}
+
+func (f *importFile) write(row []string) error {
+	err := f.writer.Write(row)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.file.Name(), err)
+	}
+
+	return nil
+}
+
+// sync flushes the rows written so far to disk and returns the size of the file.
+func (f *importFile) sync() (int64, error) {
+	f.writer.Flush()
+
+	err := f.writer.Error()
+	if err == nil {
+		err = f.file.Sync()
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", f.file.Name(), err)
+	}
+
+	size, err := f.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", f.file.Name(), err)
+	}
+
+	return size, nil
+}
+
+func (f *importFile) close() {
+	f.writer.Flush()
+	_ = f.file.Close()
+}
@@ -0,0 +1,332 @@
+package gotidal
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func importRoutes() map[string]string {
+	return map[string]string{
+		"/tracks/byIsrc": "testdata/tracks-by-isrc.json",
+		"/search":        "testdata/search-match.json",
+	}
+}
+
+func openImportFixture(t *testing.T) *os.File {
+	t.Helper()
+
+	input, err := os.Open("testdata/import.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { input.Close() })
+
+	return input
+}
+
+func readCSVFile(t *testing.T, path string) [][]string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+
+	return records
+}
+
+func TestClient_ImportCSV(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	results := filepath.Join(dir, "results.csv")
+	unmatched := filepath.Join(dir, "unmatched.csv")
+
+	client := &Client{httpClient: &mockRoutedHTTPClient{Routes: importRoutes()}, CountryCode: countryCode}
+
+	var progress []int
+
+	summary, err := client.ImportCSV(context.Background(), openImportFixture(t), results, ImportOptions{
+		BatchSize:     2,
+		UnmatchedPath: unmatched,
+		Progress:      func(summary ImportSummary) { progress = append(progress, summary.Rows) },
+	})
+	if err != nil {
+		t.Fatalf("Client.ImportCSV() error = %v", err)
+	}
+
+	want := ImportSummary{Rows: 5, Matched: 3, Unmatched: 1, Failed: 1}
+	if *summary != want {
+		t.Errorf("Client.ImportCSV() = %+v, want %+v", *summary, want)
+	}
+
+	if !reflect.DeepEqual(progress, []int{2, 4, 5}) {
+		t.Errorf("Client.ImportCSV() progress = %v, want [2 4 5]", progress)
+	}
+
+	records := readCSVFile(t, results)
+
+	wantHeader := []string{
+		"Track Name", "Artist Name(s)", "Album Name", "Duration (ms)", "ISRC", "Explicit",
+		"tidalId", "tidalTitle", "tidalArtists", "tidalAlbum", "confidence", "status", "reasons",
+	}
+	if !reflect.DeepEqual(records[0], wantHeader) {
+		t.Errorf("Client.ImportCSV() header = %q, want %q", records[0], wantHeader)
+	}
+
+	wantRows := []struct {
+		id     string
+		status string
+	}{
+		{"120259174", ImportStatusMatched},
+		{"51584179", ImportStatusMatched},
+		{"51584179", ImportStatusUnmatched},
+		{"", ImportStatusError},
+		{"100000001", ImportStatusMatched},
+	}
+
+	if len(records) != len(wantRows)+1 {
+		t.Fatalf("Client.ImportCSV() wrote %d rows, want %d", len(records)-1, len(wantRows))
+	}
+
+	for i, want := range wantRows {
+		row := records[i+1]
+		if len(row) != len(wantHeader) || row[6] != want.id || row[11] != want.status {
+			t.Errorf("Client.ImportCSV() row %d = %q, want %s %s", i+1, row, want.id, want.status)
+		}
+	}
+
+	if got := readCSVFile(t, unmatched); len(got) != 3 || got[1][0] != "Blue Monday" || got[2][1] != "Someone" {
+		t.Errorf("Client.ImportCSV() unmatched = %q", got)
+	}
+}
+
+func TestClient_ImportCSVISRCOnly(t *testing.T) {
+	t.Parallel()
+
+	results := filepath.Join(t.TempDir(), "results.csv")
+	input := strings.NewReader("id,isrc\n1,GBBLY1600675\n2,GBAAP1500379\n")
+
+	client := &Client{httpClient: &mockRoutedHTTPClient{Routes: importRoutes()}, CountryCode: countryCode}
+
+	summary, err := client.ImportCSV(context.Background(), input, results, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Client.ImportCSV() error = %v", err)
+	}
+
+	want := ImportSummary{Rows: 2, Matched: 1, Unmatched: 1}
+	if *summary != want {
+		t.Errorf("Client.ImportCSV() = %+v, want %+v", *summary, want)
+	}
+
+	records := readCSVFile(t, results)
+	if len(records) != 3 || records[1][2] != "120259174" || records[1][7] != ImportStatusMatched {
+		t.Errorf("Client.ImportCSV() = %q", records)
+	}
+}
+
+func TestClient_ImportCSVResumes(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	checkpoint := filepath.Join(dir, "results.checkpoint")
+
+	client := &Client{httpClient: &mockRoutedHTTPClient{Routes: importRoutes()}, CountryCode: countryCode}
+
+	complete := filepath.Join(dir, "complete.csv")
+
+	_, err := client.ImportCSV(context.Background(), openImportFixture(t), complete, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Client.ImportCSV() error = %v", err)
+	}
+
+	// The first run is interrupted after its first batch, leaving a partly written row behind.
+	results := filepath.Join(dir, "results.csv")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, err = client.ImportCSV(ctx, openImportFixture(t), results, ImportOptions{
+		BatchSize:      2,
+		CheckpointPath: checkpoint,
+		Progress:       func(ImportSummary) { cancel() },
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Client.ImportCSV() error = %v, want %v", err, context.Canceled)
+	}
+
+	file, err := os.OpenFile(results, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = file.WriteString("Blue Monday,New Or")
+	file.Close()
+
+	// The unmatched rows of the first run were not saved, so a resume cannot start writing them now.
+	unmatched := filepath.Join(dir, "unmatched.csv")
+
+	_, err = client.ImportCSV(context.Background(), openImportFixture(t), results, ImportOptions{
+		BatchSize:      2,
+		CheckpointPath: checkpoint,
+		UnmatchedPath:  unmatched,
+	})
+	if !errors.Is(err, ErrCheckpointMismatch) {
+		t.Fatalf("Client.ImportCSV() error = %v, want %v", err, ErrCheckpointMismatch)
+	}
+
+	if _, err := os.Stat(unmatched); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Client.ImportCSV() created the unmatched rows file on a mismatched resume: %v", err)
+	}
+
+	counting := &countingHTTPClient{
+		HTTPClient: &mockRoutedHTTPClient{Routes: importRoutes()},
+		counts:     map[string]int{},
+	}
+	resumed := &Client{httpClient: counting, CountryCode: countryCode}
+
+	summary, err := resumed.ImportCSV(context.Background(), openImportFixture(t), results, ImportOptions{
+		BatchSize:      2,
+		CheckpointPath: checkpoint,
+	})
+	if err != nil {
+		t.Fatalf("Client.ImportCSV() error = %v", err)
+	}
+
+	if summary.Rows != 5 || summary.Matched != 3 {
+		t.Errorf("Client.ImportCSV() resumed = %+v", *summary)
+	}
+
+	if counting.counts["/tracks/byIsrc"] != 0 || counting.counts["/search"] != 2 {
+		t.Errorf("Client.ImportCSV() matched the imported rows again: %v", counting.counts)
+	}
+
+	if got, want := readCSVFile(t, results), readCSVFile(t, complete); !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.ImportCSV() resumed = %q, want %q", got, want)
+	}
+
+	if _, err := os.Stat(checkpoint); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Client.ImportCSV() left the checkpoint file behind: %v", err)
+	}
+}
+
+func TestClient_ImportCSVCheckpointMismatch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	checkpoint := filepath.Join(dir, "results.checkpoint")
+
+	err := os.WriteFile(checkpoint, []byte(`{"header":["Title"],"summary":{"rows":2}}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &Client{httpClient: &mockRoutedHTTPClient{}, CountryCode: countryCode}
+
+	results := filepath.Join(dir, "results.csv")
+
+	_, err = client.ImportCSV(context.Background(), openImportFixture(t), results, ImportOptions{
+		CheckpointPath: checkpoint,
+	})
+	if !errors.Is(err, ErrCheckpointMismatch) {
+		t.Errorf("Client.ImportCSV() error = %v, want %v", err, ErrCheckpointMismatch)
+	}
+}
+
+func TestNewCSVMapping(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		header  []string
+		columns CSVColumns
+		want    csvMapping
+		wantErr error
+	}{
+		{
+			"Detected columns",
+			[]string{"Track Name", "Artist Name(s)", "Album Name", "Duration (ms)", "ISRC", "Explicit"},
+			CSVColumns{},
+			csvMapping{0, 1, 2, 3, 4, 5, true},
+			nil,
+		},
+		{
+			"Explicit columns",
+			[]string{"Song", "Performer", "Length"},
+			CSVColumns{Artist: "Performer"},
+			csvMapping{0, 1, -1, 2, -1, -1, false},
+			nil,
+		},
+		{
+			"ISRC only",
+			[]string{"id", "isrc"},
+			CSVColumns{},
+			csvMapping{-1, -1, -1, -1, 1, -1, false},
+			nil,
+		},
+		{
+			"Unknown column",
+			[]string{"Title"},
+			CSVColumns{Album: "Record"},
+			csvMapping{},
+			ErrUnknownColumn,
+		},
+		{
+			"Missing title",
+			[]string{"Artist", "Album"},
+			CSVColumns{},
+			csvMapping{},
+			ErrMissingTitleColumn,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := newCSVMapping(tt.header, tt.columns)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("newCSVMapping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && got != tt.want {
+				t.Errorf("newCSVMapping() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseImportDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value        string
+		milliseconds bool
+		want         int
+	}{
+		{"3:45", false, 225},
+		{"1:02:03", true, 3723},
+		{"225", false, 225},
+		{"224600", true, 225},
+		{"unknown", false, 0},
+		{"", true, 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseImportDuration(tt.value, tt.milliseconds); got != tt.want {
+			t.Errorf("parseImportDuration(%q, %v) = %d, want %d", tt.value, tt.milliseconds, got, tt.want)
+		}
+	}
+}
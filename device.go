@@ -0,0 +1,181 @@
+package gotidal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	deviceAuthorizationURI = "https://auth.tidal.com/v1/oauth2/device_authorization"
+	deviceCodeGrantType    = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultPollInterval is used when the device authorization response does not specify an interval, as per
+	// RFC 8628 section 3.2.
+	defaultPollInterval = 5 * time.Second
+
+	// slowDownIncrement is added to the polling interval each time the server asks the client to slow down.
+	slowDownIncrement = 5 * time.Second
+)
+
+var ErrDeviceCodeExpired = errors.New("the device code expired before the user authorized the client")
+
+// DeviceAuthorization holds the codes returned when starting a device login. The VerificationURI and UserCode should
+// be shown to the user so they can authorize the client from another device.
+// See: https://datatracker.ietf.org/doc/html/rfc8628
+type DeviceAuthorization struct {
+	DeviceCode string
+	UserCode   string
+
+	VerificationURI string
+
+	// VerificationURIComplete includes the user code so the user doesn't need to type it.
+	VerificationURIComplete string
+
+	ExpiresAt time.Time
+	Interval  time.Duration
+}
+
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"deviceCode"`
+	UserCode                string `json:"userCode"`
+	VerificationURI         string `json:"verificationUri"`
+	VerificationURIComplete string `json:"verificationUriComplete"`
+	ExpiresIn               int    `json:"expiresIn"`
+	Interval                int    `json:"interval"`
+}
+
+// oauthErrorResponse represents the error body returned by the token endpoint.
+type oauthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (c OAuthConfig) deviceAuthorizationURL() string {
+	if c.DeviceAuthorizationURL != "" {
+		return c.DeviceAuthorizationURL
+	}
+
+	return deviceAuthorizationURI
+}
+
+// DeviceAuth starts a device login for clients that cannot open a browser, such as command-line tools running on a
+// server.
+func (c OAuthConfig) DeviceAuth(ctx context.Context, httpClient HTTPClient) (*DeviceAuthorization, error) {
+	form := url.Values{}
+	form.Set("client_id", c.ClientID)
+	form.Set("scope", strings.Join(c.Scopes, " "))
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, c.deviceAuthorizationURL(), bytes.NewBufferString(form.Encode()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create device authorization request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	responseBody, err := processRequest(httpClient, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process the request: %w", err)
+	}
+
+	var response deviceAuthorizationResponse
+
+	err = json.Unmarshal(responseBody, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the device authorization response body: %w", err)
+	}
+
+	interval := time.Duration(response.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	return &DeviceAuthorization{
+		DeviceCode:              response.DeviceCode,
+		UserCode:                response.UserCode,
+		VerificationURI:         response.VerificationURI,
+		VerificationURIComplete: response.VerificationURIComplete,
+		ExpiresAt:               time.Now().Add(time.Duration(response.ExpiresIn) * time.Second),
+		Interval:                interval,
+	}, nil
+}
+
+// PollDeviceToken polls the token endpoint until the user authorizes the device login, the device code expires or
+// the context is done.
+//
+// The server can ask the client to poll less frequently, in which case the interval is increased for the remainder
+// of the login.
+func (c OAuthConfig) PollDeviceToken(
+	ctx context.Context, httpClient HTTPClient, auth *DeviceAuthorization,
+) (*UserToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", deviceCodeGrantType)
+	form.Set("client_id", c.ClientID)
+	form.Set("device_code", auth.DeviceCode)
+	form.Set("scope", strings.Join(c.Scopes, " "))
+
+	interval := auth.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped waiting for the device authorization: %w", ctx.Err())
+		case <-timer.C:
+		}
+
+		if !auth.ExpiresAt.IsZero() && time.Now().After(auth.ExpiresAt) {
+			return nil, ErrDeviceCodeExpired
+		}
+
+		token, err := c.requestToken(ctx, httpClient, form)
+		if err == nil {
+			return token, nil
+		}
+
+		switch oauthErrorCode(err) {
+		case "authorization_pending":
+		case "slow_down":
+			interval += slowDownIncrement
+		case "expired_token":
+			return nil, ErrDeviceCodeExpired
+		case "access_denied":
+			return nil, ErrAuthorizationDenied
+		default:
+			return nil, err
+		}
+
+		timer.Reset(interval)
+	}
+}
+
+// oauthErrorCode returns the OAuth error code from an unsuccessful token response, or an empty string if the error
+// did not come from the token endpoint.
+func oauthErrorCode(err error) string {
+	var responseError *ResponseError
+	if !errors.As(err, &responseError) {
+		return ""
+	}
+
+	var response oauthErrorResponse
+
+	err = json.Unmarshal(responseError.Body, &response)
+	if err != nil {
+		return ""
+	}
+
+	return response.Error
+}
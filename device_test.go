@@ -0,0 +1,134 @@
+package gotidal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOAuthConfig_DeviceAuth(t *testing.T) {
+	t.Parallel()
+
+	config := OAuthConfig{ClientID: "client-id", Scopes: []string{ScopeUserRead}}
+	httpClient := &mockHTTPClient{FilePath: "testdata/device-authorization.json", StatusCode: http.StatusOK}
+
+	auth, err := config.DeviceAuth(context.Background(), httpClient)
+	if err != nil {
+		t.Fatalf("OAuthConfig.DeviceAuth() error = %v", err)
+	}
+
+	if auth.DeviceCode != "device-code" || auth.UserCode != "ABCDE" {
+		t.Errorf("OAuthConfig.DeviceAuth() codes = %v, %v", auth.DeviceCode, auth.UserCode)
+	}
+
+	if auth.VerificationURIComplete != "link.tidal.com/ABCDE" {
+		t.Errorf("OAuthConfig.DeviceAuth() VerificationURIComplete = %v", auth.VerificationURIComplete)
+	}
+
+	if auth.Interval != 2*time.Second {
+		t.Errorf("OAuthConfig.DeviceAuth() Interval = %v, want %v", auth.Interval, 2*time.Second)
+	}
+
+	if auth.ExpiresAt.Before(time.Now()) {
+		t.Errorf("OAuthConfig.DeviceAuth() ExpiresAt = %v is in the past", auth.ExpiresAt)
+	}
+}
+
+func TestOAuthConfig_PollDeviceToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		responses []string
+		wantErr   error
+	}{
+		{
+			"Authorized after pending",
+			[]string{"authorization_pending", "authorization_pending", ""},
+			nil,
+		},
+		{
+			"Access denied",
+			[]string{"authorization_pending", "access_denied"},
+			ErrAuthorizationDenied,
+		},
+		{
+			"Expired token",
+			[]string{"expired_token"},
+			ErrDeviceCodeExpired,
+		},
+		{
+			"Unknown error",
+			[]string{"invalid_client"},
+			ErrUnexpectedResponseCode,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := int(atomic.AddInt32(&calls, 1)) - 1
+
+				if r.FormValue("grant_type") != deviceCodeGrantType || r.FormValue("device_code") != "device-code" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				if call < len(tt.responses) && tt.responses[call] != "" {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(concat(`{"error": "`, tt.responses[call], `"}`)))
+
+					return
+				}
+
+				data, err := os.ReadFile("testdata/user-token.json")
+				if err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				_, _ = w.Write(data)
+			}))
+			defer server.Close()
+
+			config := OAuthConfig{ClientID: "client-id", TokenURL: server.URL}
+			auth := &DeviceAuthorization{
+				DeviceCode: "device-code",
+				ExpiresAt:  time.Now().Add(time.Minute),
+				Interval:   time.Millisecond,
+			}
+
+			token, err := config.PollDeviceToken(context.Background(), server.Client(), auth)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OAuthConfig.PollDeviceToken() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && token.RefreshToken != "user-refresh-token" {
+				t.Errorf("OAuthConfig.PollDeviceToken() RefreshToken = %v, want %v", token.RefreshToken, "user-refresh-token")
+			}
+		})
+	}
+}
+
+func TestOAuthConfig_PollDeviceTokenCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	auth := &DeviceAuthorization{DeviceCode: "device-code", Interval: time.Hour}
+
+	_, err := OAuthConfig{}.PollDeviceToken(ctx, &mockHTTPClient{}, auth)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("OAuthConfig.PollDeviceToken() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,369 @@
+package gotidal
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	secondsPerMinute = 60
+	secondsPerHour   = 60 * secondsPerMinute
+
+	checkpointFilePerm = 0o644
+)
+
+var ErrCheckpointMismatch = errors.New("the checkpoint file belongs to another export")
+
+// Discography holds every album of an artist with its tracks.
+type Discography struct {
+	Artist Artist             `json:"artist"`
+	Albums []DiscographyAlbum `json:"albums"`
+}
+
+// DiscographyAlbum is an album in a discography with its tracks. Error is set when the tracks could not be fetched,
+// for example because the album is not available in the market.
+type DiscographyAlbum struct {
+	Album  Album   `json:"album"`
+	Tracks []Track `json:"tracks"`
+	Error  string  `json:"error,omitempty"`
+}
+
+// DiscographyOptions controls how a discography is fetched.
+type DiscographyOptions struct {
+	// CheckpointPath saves the progress of the export after each album when it is set. An interrupted export called
+	// again with the same path carries on from the last album it fetched, and the file is removed once the export
+	// completes unless KeepCheckpoint is set.
+	CheckpointPath string
+
+	// KeepCheckpoint leaves the checkpoint file in place once the export completes, so a caller that saves the
+	// discography can remove it only after the save succeeds. Calling again with a complete checkpoint returns the
+	// saved discography, only fetching the tracks of the albums that failed before.
+	KeepCheckpoint bool
+
+	// Progress is called after the tracks of each album have been fetched, with the number of albums done and the
+	// total number of albums.
+	Progress func(done int, total int)
+}
+
+// discographyCheckpoint is the progress of an export saved between runs.
+type discographyCheckpoint struct {
+	Discography
+
+	ArtistID string `json:"artistId"`
+
+	// AlbumsListed reports whether every page of albums has been fetched.
+	AlbumsListed bool `json:"albumsListed"`
+
+	// Done lists the IDs of the albums whose tracks have been fetched. Albums that failed are left out, so they are
+	// tried again when the export resumes.
+	Done []string `json:"done"`
+}
+
+// GetDiscography returns every album of an artist with its tracks, in the order the artist albums endpoint returns
+// them. An album whose tracks cannot be fetched has its Error set without stopping the export.
+func (c *Client) GetDiscography(
+	ctx context.Context, artistID string, options DiscographyOptions,
+) (*Discography, error) {
+	if artistID == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	checkpoint, err := loadDiscographyCheckpoint(options.CheckpointPath, artistID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !checkpoint.AlbumsListed {
+		err = c.listDiscographyAlbums(ctx, artistID, checkpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		err = saveDiscographyCheckpoint(options.CheckpointPath, checkpoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.fetchDiscographyTracks(ctx, checkpoint, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.CheckpointPath != "" && !options.KeepCheckpoint {
+		err = os.Remove(options.CheckpointPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove the checkpoint file: %w", err)
+		}
+	}
+
+	return &checkpoint.Discography, nil
+}
+
+// fetchDiscographyTracks fetches the tracks of each album that is not done yet, saving the checkpoint after each one.
+// Only a canceled context stops it; any other failure is recorded on the album.
+func (c *Client) fetchDiscographyTracks(
+	ctx context.Context, checkpoint *discographyCheckpoint, options DiscographyOptions,
+) error {
+	done := make(map[string]bool, len(checkpoint.Done))
+	for _, id := range checkpoint.Done {
+		done[id] = true
+	}
+
+	for i := range checkpoint.Albums {
+		album := &checkpoint.Albums[i]
+
+		if !done[album.Album.ID] {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("discography export stopped: %w", err)
+			}
+
+			tracks, err := c.GetAlbumTracks(ctx, album.Album.ID)
+			if err != nil && ctx.Err() != nil {
+				return err
+			}
+
+			album.Tracks, album.Error = tracks, ""
+
+			if err != nil {
+				album.Error = err.Error()
+			} else {
+				done[album.Album.ID] = true
+				checkpoint.Done = append(checkpoint.Done, album.Album.ID)
+			}
+
+			err = saveDiscographyCheckpoint(options.CheckpointPath, checkpoint)
+			if err != nil {
+				return err
+			}
+		}
+
+		if options.Progress != nil {
+			options.Progress(i+1, len(checkpoint.Albums))
+		}
+	}
+
+	return nil
+}
+
+// listDiscographyAlbums fetches the artist and pages through their albums.
+func (c *Client) listDiscographyAlbums(ctx context.Context, artistID string, checkpoint *discographyCheckpoint) error {
+	artist, err := c.GetSingleArtist(ctx, artistID)
+	if err != nil {
+		return err
+	}
+
+	checkpoint.Artist = *artist
+	checkpoint.Albums = nil
+
+	seen := map[string]bool{}
+	params := PaginationParams{Limit: paginationLimit}
+
+	for {
+		albums, err := c.GetAlbumsByArtist(ctx, artistID, params)
+		if err != nil {
+			return err
+		}
+
+		for _, album := range albums {
+			if !seen[album.ID] {
+				seen[album.ID] = true
+				checkpoint.Albums = append(checkpoint.Albums, DiscographyAlbum{Album: album})
+			}
+		}
+
+		if len(albums) < params.Limit {
+			break
+		}
+
+		params.Offset += params.Limit
+	}
+
+	checkpoint.AlbumsListed = true
+
+	return nil
+}
+
+func loadDiscographyCheckpoint(path string, artistID string) (*discographyCheckpoint, error) {
+	checkpoint := &discographyCheckpoint{ArtistID: artistID}
+
+	if path == "" {
+		return checkpoint, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return checkpoint, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the checkpoint file: %w", err)
+	}
+
+	err = json.Unmarshal(data, checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the checkpoint file: %w", err)
+	}
+
+	if checkpoint.ArtistID != artistID {
+		return nil, fmt.Errorf("%w: %s is for artist %s", ErrCheckpointMismatch, path, checkpoint.ArtistID)
+	}
+
+	return checkpoint, nil
+}
+
+func saveDiscographyCheckpoint(path string, checkpoint *discographyCheckpoint) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the checkpoint: %w", err)
+	}
+
+	return writeFileAtomic(path, data, checkpointFilePerm)
+}
+
+// WriteJSON writes the discography as indented JSON.
+func (d *Discography) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(d)
+	if err != nil {
+		return fmt.Errorf("failed to write the discography: %w", err)
+	}
+
+	return nil
+}
+
+// WriteCSV writes the discography with a row for each track.
+func (d *Discography) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	_ = writer.Write([]string{
+		"albumId", "albumTitle", "albumType", "releaseDate", "barcodeId", "volumeNumber", "trackNumber", "trackId",
+		"trackTitle", "version", "artists", "duration", "isrc", "mediaTags",
+	})
+
+	for _, album := range d.Albums {
+		for _, track := range album.Tracks {
+			_ = writer.Write([]string{
+				album.Album.ID, album.Album.Title, album.Album.Type, album.Album.ReleaseDate, album.Album.BarcodeID,
+				strconv.Itoa(track.VolumeNumber), strconv.Itoa(track.TrackNumber), track.ID, track.Title,
+				track.Version, artistNames(track.Artists), formatDuration(track.Duration), track.ISRC,
+				strings.Join(track.MediaMetaData.Tags, " "),
+			})
+		}
+	}
+
+	writer.Flush()
+
+	err := writer.Error()
+	if err != nil {
+		return fmt.Errorf("failed to write the discography: %w", err)
+	}
+
+	return nil
+}
+
+// WriteMarkdown writes the discography as a Markdown report with a section and a table of tracks for each album.
+func (d *Discography) WriteMarkdown(w io.Writer) error {
+	var report strings.Builder
+
+	fmt.Fprintf(&report, "# %s discography\n\n", markdownEscape(d.Artist.Name))
+	fmt.Fprintf(&report, "%d albums, %d tracks\n", len(d.Albums), d.trackCount())
+
+	for _, album := range d.Albums {
+		fmt.Fprintf(&report, "\n## %s\n\n", markdownEscape(album.Album.Title))
+		fmt.Fprintf(&report, "- Released: %s\n", album.Album.ReleaseDate)
+		fmt.Fprintf(&report, "- Type: %s\n", album.Album.Type)
+		fmt.Fprintf(&report, "- Duration: %s\n", formatDuration(album.Album.Duration))
+
+		if tags := album.Album.MediaMetaData.Tags; len(tags) > 0 {
+			fmt.Fprintf(&report, "- Quality: %s\n", strings.Join(tags, ", "))
+		}
+
+		fmt.Fprintf(&report, "- Link: %s\n", Link{Type: LinkTypeAlbum, ID: album.Album.ID}.URL())
+
+		if album.Error != "" {
+			fmt.Fprintf(&report, "- Error: %s\n", markdownEscape(album.Error))
+		}
+
+		report.WriteString("\n")
+
+		report.WriteString("| # | Title | Artists | Duration | ISRC | Quality |\n")
+		report.WriteString("|---|---|---|---|---|---|\n")
+
+		for _, track := range album.Tracks {
+			number := strconv.Itoa(track.TrackNumber)
+			if album.Album.NumberOfVolumes > 1 {
+				number = fmt.Sprintf("%d-%d", track.VolumeNumber, track.TrackNumber)
+			}
+
+			fmt.Fprintf(
+				&report, "| %s | %s | %s | %s | %s | %s |\n",
+				number, markdownEscape(trackTitle(track)), markdownEscape(artistNames(track.Artists)),
+				formatDuration(track.Duration), track.ISRC, strings.Join(track.MediaMetaData.Tags, ", "),
+			)
+		}
+	}
+
+	_, err := io.WriteString(w, report.String())
+	if err != nil {
+		return fmt.Errorf("failed to write the discography: %w", err)
+	}
+
+	return nil
+}
+
+func (d *Discography) trackCount() int {
+	count := 0
+	for _, album := range d.Albums {
+		count += len(album.Tracks)
+	}
+
+	return count
+}
+
+func trackTitle(track Track) string {
+	if track.Version == "" {
+		return track.Title
+	}
+
+	return fmt.Sprintf("%s (%s)", track.Title, track.Version)
+}
+
+func artistNames(artists []artistResource) string {
+	names := make([]string, 0, len(artists))
+	for _, artist := range artists {
+		names = append(names, artist.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// formatDuration formats seconds as m:ss, or h:mm:ss for an hour or more.
+func formatDuration(seconds int) string {
+	if seconds >= secondsPerHour {
+		return fmt.Sprintf(
+			"%d:%02d:%02d", seconds/secondsPerHour, seconds%secondsPerHour/secondsPerMinute, seconds%secondsPerMinute,
+		)
+	}
+
+	return fmt.Sprintf("%d:%02d", seconds/secondsPerMinute, seconds%secondsPerMinute)
+}
+
+// markdownEscape escapes the characters that would break a Markdown table or heading.
+func markdownEscape(text string) string {
+	return strings.NewReplacer("|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`").Replace(text)
+}
@@ -0,0 +1,321 @@
+package gotidal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// countingHTTPClient counts the requests made for each path.
+type countingHTTPClient struct {
+	HTTPClient
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (c *countingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.counts[req.URL.Path]++
+	c.mu.Unlock()
+
+	return c.HTTPClient.Do(req)
+}
+
+func discographyRoutes(albumIDs ...string) map[string]string {
+	routes := map[string]string{
+		"/artists/5907":        "testdata/single-artist.json",
+		"/artists/5907/albums": "testdata/albums-by-artist.json",
+	}
+
+	for _, id := range albumIDs {
+		routes[concat("/albums/", id, "/items")] = "testdata/album-items.json"
+	}
+
+	return routes
+}
+
+var discographyAlbumIDs = []string{ // nolint:gochecknoglobals // Test fixture IDs.
+	"308302625", "312195623", "305238210", "300255974", "291689359", "283643760", "217344854", "217339487",
+	"217225166", "200307862",
+}
+
+func TestClient_GetDiscography(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{
+		httpClient:  &mockRoutedHTTPClient{Routes: discographyRoutes(discographyAlbumIDs...)},
+		CountryCode: countryCode,
+	}
+
+	var progress []int
+
+	discography, err := client.GetDiscography(context.Background(), "5907", DiscographyOptions{
+		Progress: func(done int, _ int) { progress = append(progress, done) },
+	})
+	if err != nil {
+		t.Fatalf("Client.GetDiscography() error = %v", err)
+	}
+
+	if discography.Artist.Name != "Kronos Quartet" {
+		t.Errorf("Client.GetDiscography() Artist = %v, want Kronos Quartet", discography.Artist.Name)
+	}
+
+	if len(discography.Albums) != len(discographyAlbumIDs) {
+		t.Fatalf("Client.GetDiscography() returned %d albums, want %d", len(discography.Albums), len(discographyAlbumIDs))
+	}
+
+	if got := discography.trackCount(); got != len(discographyAlbumIDs)*8 {
+		t.Errorf("Client.GetDiscography() returned %d tracks, want %d", got, len(discographyAlbumIDs)*8)
+	}
+
+	if len(progress) != len(discographyAlbumIDs) || progress[len(progress)-1] != len(discographyAlbumIDs) {
+		t.Errorf("Client.GetDiscography() progress = %v", progress)
+	}
+}
+
+func TestClient_GetDiscographyResumes(t *testing.T) {
+	t.Parallel()
+
+	checkpoint := filepath.Join(t.TempDir(), "export.checkpoint")
+
+	// The first run is interrupted after three albums.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interrupted := &Client{
+		httpClient:  &mockRoutedHTTPClient{Routes: discographyRoutes(discographyAlbumIDs...)},
+		CountryCode: countryCode,
+	}
+
+	_, err := interrupted.GetDiscography(ctx, "5907", DiscographyOptions{
+		CheckpointPath: checkpoint,
+		Progress: func(done int, _ int) {
+			if done == 3 {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Client.GetDiscography() error = %v, want %v", err, context.Canceled)
+	}
+
+	counting := &countingHTTPClient{
+		HTTPClient: &mockRoutedHTTPClient{Routes: discographyRoutes(discographyAlbumIDs...)},
+		counts:     map[string]int{},
+	}
+	client := &Client{httpClient: counting, CountryCode: countryCode}
+
+	discography, err := client.GetDiscography(context.Background(), "5907", DiscographyOptions{CheckpointPath: checkpoint})
+	if err != nil {
+		t.Fatalf("Client.GetDiscography() error = %v", err)
+	}
+
+	if len(discography.Albums) != len(discographyAlbumIDs) || discography.trackCount() != len(discographyAlbumIDs)*8 {
+		t.Errorf("Client.GetDiscography() resumed with %d albums and %d tracks",
+			len(discography.Albums), discography.trackCount())
+	}
+
+	if counting.counts["/artists/5907/albums"] != 0 {
+		t.Error("Client.GetDiscography() listed the albums again after resuming")
+	}
+
+	for i, id := range discographyAlbumIDs {
+		want := 1
+		if i < 3 {
+			want = 0
+		}
+
+		if got := counting.counts[concat("/albums/", id, "/items")]; got != want {
+			t.Errorf("Client.GetDiscography() fetched the tracks of album %s %d times, want %d", id, got, want)
+		}
+	}
+
+	if _, err := os.Stat(checkpoint); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Client.GetDiscography() left the checkpoint file behind: %v", err)
+	}
+
+	_, err = client.GetDiscography(context.Background(), "5907", DiscographyOptions{CheckpointPath: checkpoint})
+	if err != nil {
+		t.Fatalf("Client.GetDiscography() error = %v", err)
+	}
+}
+
+func TestClient_GetDiscographyAlbumFails(t *testing.T) {
+	t.Parallel()
+
+	checkpoint := filepath.Join(t.TempDir(), "export.checkpoint")
+	options := DiscographyOptions{CheckpointPath: checkpoint, KeepCheckpoint: true}
+
+	// The tracks of the fourth album are not available, so it fails without stopping the export.
+	available := append(append([]string{}, discographyAlbumIDs[:3]...), discographyAlbumIDs[4:]...)
+	client := &Client{
+		httpClient:  &mockRoutedHTTPClient{Routes: discographyRoutes(available...)},
+		CountryCode: countryCode,
+	}
+
+	discography, err := client.GetDiscography(context.Background(), "5907", options)
+	if err != nil {
+		t.Fatalf("Client.GetDiscography() error = %v", err)
+	}
+
+	for i, album := range discography.Albums {
+		if failed := album.Error != ""; failed != (i == 3) {
+			t.Errorf("Client.GetDiscography() album %s Error = %q", album.Album.ID, album.Error)
+		}
+	}
+
+	if got := discography.trackCount(); got != (len(discographyAlbumIDs)-1)*8 {
+		t.Errorf("Client.GetDiscography() returned %d tracks, want %d", got, (len(discographyAlbumIDs)-1)*8)
+	}
+
+	// Calling again with the checkpoint only retries the album that failed.
+	counting := &countingHTTPClient{
+		HTTPClient: &mockRoutedHTTPClient{Routes: discographyRoutes(discographyAlbumIDs...)},
+		counts:     map[string]int{},
+	}
+	client = &Client{httpClient: counting, CountryCode: countryCode}
+
+	discography, err = client.GetDiscography(context.Background(), "5907", options)
+	if err != nil {
+		t.Fatalf("Client.GetDiscography() error = %v", err)
+	}
+
+	want := map[string]int{concat("/albums/", discographyAlbumIDs[3], "/items"): 1}
+	if !reflect.DeepEqual(counting.counts, want) {
+		t.Errorf("Client.GetDiscography() requests = %v, want %v", counting.counts, want)
+	}
+
+	if discography.Albums[3].Error != "" || discography.trackCount() != len(discographyAlbumIDs)*8 {
+		t.Errorf("Client.GetDiscography() did not recover album %s: %q", discographyAlbumIDs[3], discography.Albums[3].Error)
+	}
+}
+
+func TestClient_GetDiscographyKeepCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	checkpoint := filepath.Join(t.TempDir(), "export.checkpoint")
+	options := DiscographyOptions{CheckpointPath: checkpoint, KeepCheckpoint: true}
+
+	client := &Client{
+		httpClient:  &mockRoutedHTTPClient{Routes: discographyRoutes(discographyAlbumIDs...)},
+		CountryCode: countryCode,
+	}
+
+	want, err := client.GetDiscography(context.Background(), "5907", options)
+	if err != nil {
+		t.Fatalf("Client.GetDiscography() error = %v", err)
+	}
+
+	if _, err := os.Stat(checkpoint); err != nil {
+		t.Fatalf("Client.GetDiscography() removed the checkpoint file: %v", err)
+	}
+
+	counting := &countingHTTPClient{HTTPClient: &mockRoutedHTTPClient{}, counts: map[string]int{}}
+	client = &Client{httpClient: counting, CountryCode: countryCode}
+
+	got, err := client.GetDiscography(context.Background(), "5907", options)
+	if err != nil {
+		t.Fatalf("Client.GetDiscography() error = %v", err)
+	}
+
+	if len(counting.counts) != 0 {
+		t.Errorf("Client.GetDiscography() made requests with a complete checkpoint: %v", counting.counts)
+	}
+
+	if len(got.Albums) != len(want.Albums) || got.trackCount() != want.trackCount() {
+		t.Errorf("Client.GetDiscography() = %d albums, want %d", len(got.Albums), len(want.Albums))
+	}
+}
+
+func TestClient_GetDiscographyCheckpointMismatch(t *testing.T) {
+	t.Parallel()
+
+	checkpoint := filepath.Join(t.TempDir(), "export.checkpoint")
+
+	err := os.WriteFile(checkpoint, []byte(`{"artistId":"1566"}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &Client{httpClient: &mockRoutedHTTPClient{}, CountryCode: countryCode}
+
+	_, err = client.GetDiscography(context.Background(), "5907", DiscographyOptions{CheckpointPath: checkpoint})
+	if !errors.Is(err, ErrCheckpointMismatch) {
+		t.Errorf("Client.GetDiscography() error = %v, want %v", err, ErrCheckpointMismatch)
+	}
+}
+
+func TestDiscography_Write(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{
+		httpClient:  &mockRoutedHTTPClient{Routes: discographyRoutes(discographyAlbumIDs...)},
+		CountryCode: countryCode,
+	}
+
+	discography, err := client.GetDiscography(context.Background(), "5907", DiscographyOptions{})
+	if err != nil {
+		t.Fatalf("Client.GetDiscography() error = %v", err)
+	}
+
+	discography.Albums = discography.Albums[:1]
+
+	tests := []struct {
+		name      string
+		write     func(w *bytes.Buffer) error
+		wantLines int
+		want      string
+	}{
+		{"JSON", func(w *bytes.Buffer) error { return discography.WriteJSON(w) }, 0, `"albums": [`},
+		{"CSV", func(w *bytes.Buffer) error { return discography.WriteCSV(w) }, 9, "albumId,albumTitle"},
+		{"Markdown", func(w *bytes.Buffer) error { return discography.WriteMarkdown(w) }, 0, "# Kronos Quartet discography"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buffer bytes.Buffer
+
+			err := tt.write(&buffer)
+			if err != nil {
+				t.Fatalf("Discography.Write%s() error = %v", tt.name, err)
+			}
+
+			if !strings.Contains(buffer.String(), tt.want) {
+				t.Errorf("Discography.Write%s() = %q, want it to contain %q", tt.name, buffer.String(), tt.want)
+			}
+
+			if lines := strings.Count(buffer.String(), "\n"); tt.wantLines > 0 && lines != tt.wantLines {
+				t.Errorf("Discography.Write%s() wrote %d lines, want %d", tt.name, lines, tt.wantLines)
+			}
+		})
+	}
+}
+
+func Test_formatDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		seconds int
+		want    string
+	}{
+		{0, "0:00"},
+		{315, "5:15"},
+		{3600, "1:00:00"},
+		{4321, "1:12:01"},
+	}
+	for _, tt := range tests {
+		if got := formatDuration(tt.seconds); got != tt.want {
+			t.Errorf("formatDuration(%d) = %v, want %v", tt.seconds, got, tt.want)
+		}
+	}
+}
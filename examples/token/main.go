@@ -1,17 +1,40 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
 
 	"github.com/tomjowitt/gotidal"
 )
 
 func main() {
-	clientID := os.Getenv("TIDAL_CLIENT_ID")
-	clientSecret := os.Getenv("TIDAL_CLIENT_SECRET")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	client, err := gotidal.NewClient(clientID, clientSecret, "AU")
+	config := gotidal.OAuthConfig{
+		ClientID:     os.Getenv("TIDAL_CLIENT_ID"),
+		ClientSecret: os.Getenv("TIDAL_CLIENT_SECRET"),
+		Scopes:       []string{gotidal.ScopeUserRead},
+	}
+
+	httpClient := &http.Client{}
+
+	auth, err := config.DeviceAuth(ctx, httpClient)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("-------------------------------------------------")
+	log.Println("Device Login")
+	log.Println("-------------------------------------------------")
+
+	log.Printf("Visit https://%s and enter the code %s", auth.VerificationURI, auth.UserCode)
+	log.Printf("Or open https://%s", auth.VerificationURIComplete)
+
+	token, err := config.PollDeviceToken(ctx, httpClient, auth)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -20,5 +43,7 @@ func main() {
 	log.Println("OAuth Token")
 	log.Println("-------------------------------------------------")
 
-	log.Println(client.Token)
+	log.Printf("Access token: %s", token.AccessToken)
+	log.Printf("Refresh token: %s", token.RefreshToken)
+	log.Printf("Expires: %s", token.Expiry)
 }
@@ -0,0 +1,222 @@
+package gotidal
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	isrcLength = 12
+
+	// The ISRC components end at these offsets, followed by the designation code.
+	isrcCountryEnd    = 2
+	isrcRegistrantEnd = 5
+	isrcYearEnd       = 7
+
+	ean13Length = 13
+
+	// EAN-13 check digits make the weighted sum of the digits a multiple of 10, weighting every other digit by 3.
+	checkDigitModulus = 10
+	checkDigitWeight  = 3
+
+	// minBarcodeLength allows for UPC-A codes that lost their leading zero by being stored as a number.
+	minBarcodeLength = 11
+
+	// centuryYears splits the two-digit ISRC reference year between the 1900s and 2000s.
+	centuryYears = 100
+)
+
+var (
+	ErrInvalidISRC    = errors.New("the ISRC must be 12 characters in the form CC-XXX-YY-NNNNN")
+	ErrInvalidBarcode = errors.New("the barcode must be a UPC-A or EAN-13 code with a valid check digit")
+)
+
+// ISRC is a normalized International Standard Recording Code, such as GBAYE6700149.
+type ISRC string
+
+// ParseISRC strips hyphens, spaces and an optional "ISRC" prefix, converts the code to upper case and checks its
+// format.
+func ParseISRC(code string) (ISRC, error) {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToUpper(code))
+	if len(normalized) > isrcLength {
+		normalized = strings.TrimPrefix(strings.TrimPrefix(normalized, "ISRC"), ":")
+	}
+
+	isrc := ISRC(normalized)
+	if !isrc.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidISRC, code)
+	}
+
+	return isrc, nil
+}
+
+// Valid reports whether the ISRC is in its normalized form: a two letter country code, a three character registrant
+// code, a two digit year and a five digit designation code.
+func (i ISRC) Valid() bool {
+	if len(i) != isrcLength {
+		return false
+	}
+
+	for index, char := range i {
+		switch {
+		case index < isrcCountryEnd:
+			if char < 'A' || char > 'Z' {
+				return false
+			}
+		case index < isrcRegistrantEnd:
+			if (char < 'A' || char > 'Z') && (char < '0' || char > '9') {
+				return false
+			}
+		default:
+			if char < '0' || char > '9' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// String returns the ISRC without hyphens.
+func (i ISRC) String() string {
+	return string(i)
+}
+
+// Hyphenated returns the ISRC in its display form, such as GB-AYE-67-00149.
+func (i ISRC) Hyphenated() string {
+	if !i.Valid() {
+		return string(i)
+	}
+
+	return strings.Join([]string{
+		i.Country(), i.Registrant(), string(i[isrcRegistrantEnd:isrcYearEnd]), i.Designation(),
+	}, "-")
+}
+
+// Country returns the country code of the registrant. It is usually an ISO 3166-1 alpha-2 code, but some codes
+// are reserved for the ISRC agency.
+func (i ISRC) Country() string {
+	if !i.Valid() {
+		return ""
+	}
+
+	return string(i[:isrcCountryEnd])
+}
+
+// Registrant returns the code of the label or rights holder that assigned the ISRC.
+func (i ISRC) Registrant() string {
+	if !i.Valid() {
+		return ""
+	}
+
+	return string(i[isrcCountryEnd:isrcRegistrantEnd])
+}
+
+// Year returns the four digit year the ISRC was assigned. Two digit years ahead of the current year are treated as
+// being in the 1900s.
+func (i ISRC) Year() int {
+	if !i.Valid() {
+		return 0
+	}
+
+	year, _ := strconv.Atoi(string(i[isrcRegistrantEnd:isrcYearEnd]))
+	current := time.Now().Year()
+
+	year += current - current%centuryYears
+	if year > current {
+		year -= centuryYears
+	}
+
+	return year
+}
+
+// Designation returns the five digit code that identifies the recording within the registrant and year.
+func (i ISRC) Designation() string {
+	if !i.Valid() {
+		return ""
+	}
+
+	return string(i[isrcYearEnd:])
+}
+
+// Barcode is a product barcode normalized to its 13 digit EAN-13 form. UPC-A codes are EAN-13 codes with a leading
+// zero.
+type Barcode string
+
+// ParseBarcode strips spaces and hyphens, restores leading zeros that were lost by storing the barcode as a number,
+// and checks the check digit. UPC-A codes are converted to EAN-13.
+func ParseBarcode(code string) (Barcode, error) {
+	digits := strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code))
+
+	if len(digits) < minBarcodeLength || len(digits) > ean13Length {
+		return "", fmt.Errorf("%w: %q", ErrInvalidBarcode, code)
+	}
+
+	barcode := Barcode(concat(strings.Repeat("0", ean13Length-len(digits)), digits))
+	if !barcode.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidBarcode, code)
+	}
+
+	return barcode, nil
+}
+
+// Valid reports whether the barcode is 13 digits long and its last digit is the correct check digit.
+func (b Barcode) Valid() bool {
+	if len(b) != ean13Length {
+		return false
+	}
+
+	sum := 0
+
+	for index, char := range b {
+		if char < '0' || char > '9' {
+			return false
+		}
+
+		digit := int(char - '0')
+
+		if index == ean13Length-1 {
+			return (sum+digit)%checkDigitModulus == 0
+		}
+
+		if index%2 == 1 {
+			digit *= checkDigitWeight
+		}
+
+		sum += digit
+	}
+
+	return false
+}
+
+// String returns the EAN-13 form of the barcode.
+func (b Barcode) String() string {
+	return string(b)
+}
+
+// EAN13 returns the 13 digit EAN-13 form of the barcode.
+func (b Barcode) EAN13() string {
+	return string(b)
+}
+
+// UPCA returns the 12 digit UPC-A form of the barcode. Only EAN-13 codes with a leading zero have a UPC-A form.
+func (b Barcode) UPCA() (string, bool) {
+	if !b.Valid() || b[0] != '0' {
+		return "", false
+	}
+
+	return string(b[1:]), true
+}
+
+// lookupForms returns the forms of the barcode to search for, most likely first. Labels in the US and Canada
+// register UPC-A codes, so those are looked up before the EAN-13 form.
+func (b Barcode) lookupForms() []string {
+	if upcA, ok := b.UPCA(); ok {
+		return []string{upcA, b.EAN13()}
+	}
+
+	return []string{b.EAN13()}
+}
@@ -0,0 +1,167 @@
+package gotidal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseISRC(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		code    string
+		want    ISRC
+		wantErr bool
+	}{
+		{"Normalized", "GBAYE6700149", "GBAYE6700149", false},
+		{"Hyphenated", "GB-AYE-67-00149", "GBAYE6700149", false},
+		{"Lower case with spaces", " gb aye 67 00149 ", "GBAYE6700149", false},
+		{"Prefixed", "ISRC: GB-AYE-67-00149", "GBAYE6700149", false},
+		{"Country code starting with IS", "ISRCA1200001", "ISRCA1200001", false},
+		{"Too short", "GBAYE670014", "", true},
+		{"Numeric country", "12AYE6700149", "", true},
+		{"Letters in the designation", "GBAYE67001A9", "", true},
+		{"Track ID", "51584179", "", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseISRC(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseISRC() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, ErrInvalidISRC) {
+				t.Errorf("ParseISRC() error = %v, want %v", err, ErrInvalidISRC)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseISRC() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestISRC_components(t *testing.T) {
+	t.Parallel()
+
+	isrc := ISRC("GBAYE6700149")
+
+	got := []string{isrc.Country(), isrc.Registrant(), isrc.Designation(), isrc.Hyphenated()}
+	want := []string{"GB", "AYE", "00149", "GB-AYE-67-00149"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ISRC components = %v, want %v", got, want)
+	}
+
+	if got := isrc.Year(); got != 1967 {
+		t.Errorf("ISRC.Year() = %v, want 1967", got)
+	}
+
+	if got := ISRC("USSM12204871").Year(); got != 2022 {
+		t.Errorf("ISRC.Year() = %v, want 2022", got)
+	}
+
+	current := time.Now().Year()
+	if got := ISRC(fmt.Sprintf("GBAYE%02d00001", current%100)).Year(); got != current {
+		t.Errorf("ISRC.Year() = %v, want the current year %v", got, current)
+	}
+
+	if got := ISRC("invalid").Country(); got != "" {
+		t.Errorf("ISRC.Country() = %v for an invalid ISRC", got)
+	}
+}
+
+func TestParseBarcode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		code     string
+		want     Barcode
+		wantUPCA string
+		wantErr  bool
+	}{
+		{"UPC-A", "825646008339", "0825646008339", "825646008339", false},
+		{"EAN-13", "0825646008339", "0825646008339", "825646008339", false},
+		{"UPC-A without its leading zero", "93074025154", "0093074025154", "093074025154", false},
+		{"Hyphenated EAN-13", "4-006381-333931", "4006381333931", "", false},
+		{"Invalid check digit", "825646008338", "", "", true},
+		{"Too short", "8256460", "", "", true},
+		{"Too long", "08256460083391", "", "", true},
+		{"Not a number", "82564600833X", "", "", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseBarcode(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBarcode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, ErrInvalidBarcode) {
+				t.Errorf("ParseBarcode() error = %v, want %v", err, ErrInvalidBarcode)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseBarcode() = %v, want %v", got, tt.want)
+			}
+
+			if upcA, _ := got.UPCA(); upcA != tt.wantUPCA {
+				t.Errorf("Barcode.UPCA() = %v, want %v", upcA, tt.wantUPCA)
+			}
+		})
+	}
+}
+
+func TestGetAlbumByBarcodeID(t *testing.T) {
+	t.Parallel()
+
+	var barcodes []string
+
+	// The album is registered under its EAN-13 form, so the UPC-A lookup returns no results.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		barcode := r.URL.Query().Get("barcodeId")
+		barcodes = append(barcodes, barcode)
+
+		if barcode != "0825646008339" {
+			_, _ = w.Write([]byte(`{"data":[]}`))
+
+			return
+		}
+
+		http.ServeFile(w, r, "testdata/multiple-albums.json")
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client(), Environment: server.URL, CountryCode: countryCode}
+
+	albums, err := client.GetAlbumByBarcodeID(context.Background(), "825646008339")
+	if err != nil {
+		t.Fatalf("Client.GetAlbumByBarcodeID() error = %v", err)
+	}
+
+	if len(albums) == 0 {
+		t.Error("Client.GetAlbumByBarcodeID() returned no albums")
+	}
+
+	if want := []string{"825646008339", "0825646008339"}; !reflect.DeepEqual(barcodes, want) {
+		t.Errorf("Client.GetAlbumByBarcodeID() looked up %v, want %v", barcodes, want)
+	}
+
+	_, err = client.GetAlbumByBarcodeID(context.Background(), "825646008338")
+	if !errors.Is(err, ErrInvalidBarcode) {
+		t.Errorf("Client.GetAlbumByBarcodeID() error = %v, want %v", err, ErrInvalidBarcode)
+	}
+}
@@ -1,8 +1,238 @@
 package gotidal
 
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ImageFitCover selects the smallest image that covers the requested size, so it can be scaled down and cropped
+	// without losing sharpness.
+	ImageFitCover = "cover"
+
+	// ImageFitContain selects the largest image that fits within the requested size without being scaled up.
+	ImageFitContain = "contain"
+
+	// ImageKindAlbumCover, ImageKindArtistPicture and ImageKindVideo select the sizes ImageURL.Resize can produce.
+	ImageKindAlbumCover    = "albumCover"
+	ImageKindArtistPicture = "artistPicture"
+	ImageKindVideo         = "video"
+
+	imageHost       = "resources.tidal.com"
+	imagePathPrefix = "/images/"
+
+	// imageIDParts is the number of path segments an image ID is split into.
+	imageIDParts = 5
+
+	// aspectTolerance treats aspect ratios within 2% of each other as the same, to allow for rounded dimensions.
+	aspectTolerance = 0.02
+)
+
+var (
+	ErrUnrecognizedImageURL = errors.New("the URL is not a resources.tidal.com image URL")
+	ErrUnknownImageKind     = errors.New("the image kind must be one of albumCover, artistPicture or video")
+)
+
 // Image represents an individual image.
 type Image struct {
 	URL    string `json:"url"`
 	Width  int    `json:"width"`
 	Height int    `json:"height"`
 }
+
+// BestImage selects the image that best matches a display size from a list of sizes.
+//
+// Images with the aspect ratio closest to width by height are preferred, so a square slot gets a square cover rather
+// than a wide banner. Among those, fit selects the image: ImageFitCover picks the smallest image at least as large as
+// the slot, falling back to the largest, and ImageFitContain picks the largest image no larger than the slot, falling
+// back to the smallest. A zero width or height leaves that dimension unconstrained. It returns false when there are
+// no images.
+func BestImage(images []Image, width int, height int, fit string) (Image, bool) {
+	candidates := closestAspectRatio(images, width, height)
+	if len(candidates) == 0 {
+		return Image{}, false
+	}
+
+	var (
+		best  Image
+		found bool
+	)
+
+	for _, image := range candidates {
+		covers := image.Width >= width && image.Height >= height
+		fits := (width <= 0 || image.Width <= width) && (height <= 0 || image.Height <= height)
+
+		switch {
+		case fit == ImageFitContain && fits:
+			if !found || imageArea(image) > imageArea(best) {
+				best, found = image, true
+			}
+		case fit != ImageFitContain && covers:
+			if !found || imageArea(image) < imageArea(best) {
+				best, found = image, true
+			}
+		}
+	}
+
+	if found {
+		return best, true
+	}
+
+	// No image matches the fit, so fall back to the closest: the largest image to cover or the smallest to contain.
+	best = candidates[0]
+
+	for _, image := range candidates[1:] {
+		smaller, larger := imageArea(image) < imageArea(best), imageArea(image) > imageArea(best)
+		if (fit == ImageFitContain && smaller) || (fit != ImageFitContain && larger) {
+			best = image
+		}
+	}
+
+	return best, true
+}
+
+func imageArea(image Image) int {
+	return image.Width * image.Height
+}
+
+// closestAspectRatio returns the images whose aspect ratio is closest to width by height.
+func closestAspectRatio(images []Image, width int, height int) []Image {
+	if width <= 0 || height <= 0 {
+		return images
+	}
+
+	target := float64(width) / float64(height)
+	distances := make([]float64, len(images))
+	closest := math.Inf(1)
+
+	for i, image := range images {
+		distances[i] = math.Inf(1)
+
+		if image.Width > 0 && image.Height > 0 {
+			distances[i] = math.Abs(math.Log(float64(image.Width) / float64(image.Height) / target))
+		}
+
+		closest = math.Min(closest, distances[i])
+	}
+
+	var candidates []Image
+
+	for i, image := range images {
+		if distances[i] <= closest+aspectTolerance {
+			candidates = append(candidates, image)
+		}
+	}
+
+	return candidates
+}
+
+// ImageURL is a parsed resources.tidal.com image URL, such as
+// https://resources.tidal.com/images/34d80a5c/90b5/478b/985d/caa3a72029f5/320x320.jpg.
+type ImageURL struct {
+	// ID is the UUID of the image, such as 34d80a5c-90b5-478b-985d-caa3a72029f5.
+	ID     string
+	Width  int
+	Height int
+
+	// Format is the file extension of the image.
+	// Example: jpg, png
+	Format string
+}
+
+// ParseImageURL parses a resources.tidal.com image URL into its image ID, size and format.
+func ParseImageURL(rawURL string) (ImageURL, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() != imageHost || !strings.HasPrefix(parsed.Path, imagePathPrefix) {
+		return ImageURL{}, fmt.Errorf("%w: %q", ErrUnrecognizedImageURL, rawURL)
+	}
+
+	segments := strings.Split(strings.TrimPrefix(parsed.Path, imagePathPrefix), "/")
+	if len(segments) != imageIDParts+1 {
+		return ImageURL{}, fmt.Errorf("%w: %q", ErrUnrecognizedImageURL, rawURL)
+	}
+
+	size, format, _ := strings.Cut(segments[imageIDParts], ".")
+	widthText, heightText, _ := strings.Cut(size, "x")
+
+	width, widthErr := strconv.Atoi(widthText)
+	height, heightErr := strconv.Atoi(heightText)
+
+	if widthErr != nil || heightErr != nil || format == "" {
+		return ImageURL{}, fmt.Errorf("%w: %q", ErrUnrecognizedImageURL, rawURL)
+	}
+
+	return ImageURL{
+		ID:     strings.Join(segments[:imageIDParts], "-"),
+		Width:  width,
+		Height: height,
+		Format: format,
+	}, nil
+}
+
+// String returns the URL of the image.
+func (u ImageURL) String() string {
+	return fmt.Sprintf(
+		"https://%s%s%s/%dx%d.%s", imageHost, imagePathPrefix, strings.ReplaceAll(u.ID, "-", "/"), u.Width, u.Height,
+		u.Format,
+	)
+}
+
+// Resize returns the same image at the size TIDAL serves for its kind that best covers width by height, choosing
+// between the sizes in the same way as BestImage with ImageFitCover.
+//
+// TIDAL only generates a fixed set of sizes for each kind of image and other sizes return an error from the image
+// server: 80, 160, 320, 640 and 1280 pixel square album covers, 160, 320, 480 and 750 pixel square artist pictures,
+// and 160x107, 480x320, 750x500 and 1080x720 video images.
+func (u ImageURL) Resize(kind string, width int, height int) (Image, error) {
+	sizes, err := imageSizes(kind)
+	if err != nil {
+		return Image{}, err
+	}
+
+	size, _ := BestImage(sizes, width, height, ImageFitCover)
+	u.Width, u.Height = size.Width, size.Height
+
+	return Image{URL: u.String(), Width: size.Width, Height: size.Height}, nil
+}
+
+// imageSizes returns the sizes TIDAL generates for a kind of image.
+func imageSizes(kind string) ([]Image, error) {
+	var sizes [][2]int
+
+	switch kind {
+	case ImageKindAlbumCover:
+		sizes = [][2]int{{80, 80}, {160, 160}, {320, 320}, {640, 640}, {1280, 1280}}
+	case ImageKindArtistPicture:
+		sizes = [][2]int{{160, 160}, {320, 320}, {480, 480}, {750, 750}}
+	case ImageKindVideo:
+		sizes = [][2]int{{160, 107}, {480, 320}, {750, 500}, {1080, 720}}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownImageKind, kind)
+	}
+
+	images := make([]Image, 0, len(sizes))
+	for _, size := range sizes {
+		images = append(images, Image{Width: size[0], Height: size[1]})
+	}
+
+	return images, nil
+}
+
+// BestCover returns the album cover that best fits a display size. See BestImage.
+func (a AlbumResource) BestCover(width int, height int) (Image, bool) {
+	return BestImage(a.ImageCover, width, height, ImageFitCover)
+}
+
+// BestPicture returns the artist picture that best fits a display size. See BestImage.
+func (a artistResource) BestPicture(width int, height int) (Image, bool) {
+	return BestImage(a.Picture, width, height, ImageFitCover)
+}
+
+// BestImage returns the video image that best fits a display size. See BestImage.
+func (v videoResource) BestImage(width int, height int) (Image, bool) {
+	return BestImage(v.Images, width, height, ImageFitCover)
+}
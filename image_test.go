@@ -0,0 +1,166 @@
+package gotidal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBestImage(t *testing.T) {
+	t.Parallel()
+
+	images := []Image{
+		{URL: "1024x256", Width: 1024, Height: 256},
+		{URL: "1080x720", Width: 1080, Height: 720},
+		{URL: "160x107", Width: 160, Height: 107},
+		{URL: "160x160", Width: 160, Height: 160},
+		{URL: "320x214", Width: 320, Height: 214},
+		{URL: "320x320", Width: 320, Height: 320},
+		{URL: "480x480", Width: 480, Height: 480},
+		{URL: "640x428", Width: 640, Height: 428},
+	}
+
+	tests := []struct {
+		name   string
+		images []Image
+		width  int
+		height int
+		fit    string
+		want   string
+		ok     bool
+	}{
+		{"Square cover", images, 300, 300, ImageFitCover, "320x320", true},
+		{"Square contain", images, 300, 300, ImageFitContain, "160x160", true},
+		{"Exact size", images, 480, 480, ImageFitCover, "480x480", true},
+		{"Square larger than any image", images, 1000, 1000, ImageFitCover, "480x480", true},
+		{"Square smaller than any image", images, 100, 100, ImageFitContain, "160x160", true},
+		{"Landscape", images, 600, 400, ImageFitCover, "640x428", true},
+		{"Banner", images, 800, 200, ImageFitCover, "1024x256", true},
+		{"Width only", images, 1000, 0, ImageFitCover, "1024x256", true},
+		{"Default fit covers", images, 200, 200, "", "320x320", true},
+		{"No images", nil, 320, 320, ImageFitCover, "", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := BestImage(tt.images, tt.width, tt.height, tt.fit)
+			if ok != tt.ok || got.URL != tt.want {
+				t.Errorf("BestImage() = %v, %v, want %v, %v", got.URL, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseImageURL(t *testing.T) {
+	t.Parallel()
+
+	rawURL := "https://resources.tidal.com/images/34d80a5c/90b5/478b/985d/caa3a72029f5/1024x256.jpg"
+
+	got, err := ParseImageURL(rawURL)
+	if err != nil {
+		t.Fatalf("ParseImageURL() error = %v", err)
+	}
+
+	want := ImageURL{ID: "34d80a5c-90b5-478b-985d-caa3a72029f5", Width: 1024, Height: 256, Format: "jpg"}
+	if got != want {
+		t.Errorf("ParseImageURL() = %v, want %v", got, want)
+	}
+
+	if got.String() != rawURL {
+		t.Errorf("ImageURL.String() = %v, want %v", got.String(), rawURL)
+	}
+
+	if _, err := got.Resize("banner", 640, 640); !errors.Is(err, ErrUnknownImageKind) {
+		t.Errorf("ImageURL.Resize() error = %v, want %v", err, ErrUnknownImageKind)
+	}
+
+	for _, invalid := range []string{
+		"https://example.com/images/34d80a5c/90b5/478b/985d/caa3a72029f5/1024x256.jpg",
+		"https://resources.tidal.com/images/34d80a5c/1024x256.jpg",
+		"https://resources.tidal.com/images/34d80a5c/90b5/478b/985d/caa3a72029f5/large.jpg",
+		"https://resources.tidal.com/images/34d80a5c/90b5/478b/985d/caa3a72029f5/1024x256",
+	} {
+		if _, err := ParseImageURL(invalid); !errors.Is(err, ErrUnrecognizedImageURL) {
+			t.Errorf("ParseImageURL(%s) error = %v, want %v", invalid, err, ErrUnrecognizedImageURL)
+		}
+	}
+}
+
+func TestImageURL_Resize(t *testing.T) {
+	t.Parallel()
+
+	image := ImageURL{ID: "34d80a5c-90b5-478b-985d-caa3a72029f5", Width: 1024, Height: 256, Format: "jpg"}
+
+	tests := []struct {
+		kind   string
+		width  int
+		height int
+		want   string
+	}{
+		{ImageKindAlbumCover, 640, 640, "640x640"},
+		{ImageKindAlbumCover, 500, 500, "640x640"},
+		{ImageKindAlbumCover, 3000, 3000, "1280x1280"},
+		{ImageKindAlbumCover, 0, 100, "160x160"},
+		{ImageKindArtistPicture, 400, 400, "480x480"},
+		{ImageKindArtistPicture, 1000, 0, "750x750"},
+		{ImageKindVideo, 600, 400, "750x500"},
+		{ImageKindVideo, 100, 100, "160x107"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(fmt.Sprintf("%s %dx%d", tt.kind, tt.width, tt.height), func(t *testing.T) {
+			t.Parallel()
+
+			got, err := image.Resize(tt.kind, tt.width, tt.height)
+			if err != nil {
+				t.Fatalf("ImageURL.Resize() error = %v", err)
+			}
+
+			want := concat("https://resources.tidal.com/images/34d80a5c/90b5/478b/985d/caa3a72029f5/", tt.want, ".jpg")
+			if got.URL != want || fmt.Sprintf("%dx%d", got.Width, got.Height) != tt.want {
+				t.Errorf("ImageURL.Resize() = %+v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestImageAccessors(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{
+		httpClient: &mockRoutedHTTPClient{Routes: map[string]string{
+			"/albums/51584178": "testdata/single-album.json",
+			"/videos/75623239": "testdata/single-video.json",
+		}},
+		CountryCode: countryCode,
+	}
+
+	album, err := client.GetSingleAlbum(context.Background(), "51584178")
+	if err != nil {
+		t.Fatalf("Client.GetSingleAlbum() error = %v", err)
+	}
+
+	if cover, ok := album.BestCover(300, 300); !ok || cover.Width != cover.Height || cover.Width < 300 {
+		t.Errorf("Album.BestCover() = %v, %v, want a square cover of at least 300px", cover, ok)
+	}
+
+	if picture, ok := album.Artists[0].BestPicture(160, 160); !ok || picture.Width != 160 || picture.Height != 160 {
+		t.Errorf("Artist.BestPicture() = %v, %v, want the 160x160 picture", picture, ok)
+	}
+
+	video, err := client.GetSingleVideo(context.Background(), "75623239")
+	if err != nil {
+		t.Fatalf("Client.GetSingleVideo() error = %v", err)
+	}
+
+	if image, ok := video.BestImage(640, 360); !ok || image.Width != 640 {
+		t.Errorf("Video.BestImage() = %v, %v, want the 640x360 image", image, ok)
+	}
+
+	if _, ok := (&Album{}).BestCover(320, 320); ok {
+		t.Error("Album.BestCover() found a cover for an album without images")
+	}
+}
@@ -0,0 +1,293 @@
+package gotidal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	jsonAPIContentType = "application/vnd.api+json"
+	environmentV2      = "https://openapi.tidal.com/v2"
+)
+
+var ErrNotFound = errors.New("the requested resource was not found")
+
+// Document represents a top-level JSON:API document returned by the TIDAL v2 API.
+// See: https://jsonapi.org/format/#document-top-level
+type Document struct {
+	Data     ResourceData     `json:"data"`
+	Included []ResourceObject `json:"included"`
+	Links    Links            `json:"links"`
+}
+
+// ResourceData holds the primary data of a document, which can either be a single resource or a list of resources.
+type ResourceData struct {
+	// Many reports whether the document contained a list of resources rather than a single resource.
+	Many      bool
+	Resources []ResourceObject
+}
+
+// UnmarshalJSON decodes either a single resource object, a list of resource objects or null.
+func (d *ResourceData) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		d.Many = false
+		d.Resources = nil
+	case bytes.HasPrefix(data, []byte("[")):
+		d.Many = true
+
+		return json.Unmarshal(data, &d.Resources) // nolint:wrapcheck // Wrapped by the caller.
+	default:
+		var resource ResourceObject
+
+		err := json.Unmarshal(data, &resource)
+		if err != nil {
+			return err // nolint:wrapcheck // Wrapped by the caller.
+		}
+
+		d.Many = false
+		d.Resources = []ResourceObject{resource}
+	}
+
+	return nil
+}
+
+// ResourceIdentifier uniquely identifies a resource by its type and ID.
+type ResourceIdentifier struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// ResourceObject represents an individual JSON:API resource. Attributes are kept raw so they can be decoded into the
+// typed models for each resource type.
+type ResourceObject struct {
+	ResourceIdentifier
+	Attributes    json.RawMessage         `json:"attributes,omitempty"`
+	Relationships map[string]Relationship `json:"relationships,omitempty"`
+	Links         *Links                  `json:"links,omitempty"`
+}
+
+// DecodeAttributes unmarshals the attributes of the resource into v.
+func (r ResourceObject) DecodeAttributes(v any) error {
+	if len(r.Attributes) == 0 {
+		return nil
+	}
+
+	err := json.Unmarshal(r.Attributes, v)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal the %s attributes: %w", r.Type, err)
+	}
+
+	return nil
+}
+
+// Relationship represents the linkage between a resource and other resources.
+type Relationship struct {
+	Data  ResourceLinkage `json:"data"`
+	Links Links           `json:"links"`
+}
+
+// ResourceLinkage holds the identifiers of related resources, which can either be a single identifier or a list.
+type ResourceLinkage []ResourceIdentifier
+
+// UnmarshalJSON decodes either a single resource identifier, a list of resource identifiers or null.
+func (l *ResourceLinkage) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*l = nil
+	case bytes.HasPrefix(data, []byte("[")):
+		var identifiers []ResourceIdentifier
+
+		err := json.Unmarshal(data, &identifiers)
+		if err != nil {
+			return err // nolint:wrapcheck // Wrapped by the caller.
+		}
+
+		*l = identifiers
+	default:
+		var identifier ResourceIdentifier
+
+		err := json.Unmarshal(data, &identifier)
+		if err != nil {
+			return err // nolint:wrapcheck // Wrapped by the caller.
+		}
+
+		*l = ResourceLinkage{identifier}
+	}
+
+	return nil
+}
+
+// Links represents the links object of a document, resource or relationship.
+type Links struct {
+	Self string `json:"self,omitempty"`
+	Next string `json:"next,omitempty"`
+}
+
+// V2Params defines the common query parameters supported by the TIDAL v2 API endpoints.
+type V2Params struct {
+	// Include lists the relationships to return as included resources.
+	// Example: artists, albums
+	Include []string
+
+	// Fields restricts the attributes returned for each resource type (sparse fieldsets).
+	// Example: map[string][]string{"albums": {"title", "releaseDate"}}
+	Fields map[string][]string
+}
+
+func (p V2Params) values(countryCode string) url.Values {
+	values := url.Values{}
+	values.Set("countryCode", countryCode)
+
+	if len(p.Include) > 0 {
+		values.Set("include", strings.Join(p.Include, ","))
+	}
+
+	for resourceType, fields := range p.Fields {
+		values.Set(concat("fields[", resourceType, "]"), strings.Join(fields, ","))
+	}
+
+	return values
+}
+
+// includedIndex provides lookups of included resources by their type and ID.
+type includedIndex map[ResourceIdentifier]ResourceObject
+
+func newIncludedIndex(doc *Document) includedIndex {
+	index := make(includedIndex, len(doc.Included)+len(doc.Data.Resources))
+
+	for _, resource := range doc.Data.Resources {
+		index[resource.ResourceIdentifier] = resource
+	}
+
+	for _, resource := range doc.Included {
+		index[resource.ResourceIdentifier] = resource
+	}
+
+	return index
+}
+
+// related returns the included resources for a relationship, in the order they are linked. Identifiers that are not
+// present in the included resources are returned as resources with no attributes.
+func (i includedIndex) related(resource ResourceObject, name string) []ResourceObject {
+	relationship, ok := resource.Relationships[name]
+	if !ok {
+		return nil
+	}
+
+	related := make([]ResourceObject, 0, len(relationship.Data))
+
+	for _, identifier := range relationship.Data {
+		related = append(related, i.resource(identifier))
+	}
+
+	return related
+}
+
+// resource returns the included resource for an identifier, or a resource with only its type and ID when the
+// document does not include it.
+func (i includedIndex) resource(identifier ResourceIdentifier) ResourceObject {
+	included, ok := i[identifier]
+	if !ok {
+		return ResourceObject{ResourceIdentifier: identifier}
+	}
+
+	return included
+}
+
+// requestV2 makes a request to the TIDAL v2 API and decodes the JSON:API document. Responses without a body return
+// an empty document.
+//
+// nolint:unparam // Reads are always GET requests.
+func (c *Client) requestV2(ctx context.Context, method string, path string, query url.Values) (*Document, error) {
+	return c.requestV2URL(ctx, method, concat(c.EnvironmentV2, path, "?", encodeQuery(query)), requestOptions{})
+}
+
+func (c *Client) requestV2URL(
+	ctx context.Context, method string, uri string, options requestOptions,
+) (*Document, error) {
+	response, err := c.do(ctx, method, uri, jsonAPIContentType, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc Document
+
+	if len(bytes.TrimSpace(response.Body)) == 0 {
+		return &doc, nil
+	}
+
+	err = json.Unmarshal(response.Body, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the JSON:API document: %w", err)
+	}
+
+	return &doc, nil
+}
+
+// requestV2Pages follows the cursor pagination links of a v2 endpoint and calls fn for each page until there are no
+// more pages.
+func (c *Client) requestV2Pages(
+	ctx context.Context, path string, query url.Values, fn func(doc *Document) error,
+) error {
+	doc, err := c.requestV2(ctx, http.MethodGet, path, query)
+
+	for {
+		if err != nil {
+			return err
+		}
+
+		err = fn(doc)
+		if err != nil {
+			return err
+		}
+
+		if doc.Links.Next == "" {
+			return nil
+		}
+
+		doc, err = c.requestV2URL(ctx, http.MethodGet, c.resolveV2Link(doc.Links.Next), requestOptions{})
+	}
+}
+
+// resolveV2Link turns a link returned by the v2 API into an absolute URL. Pagination links are returned relative to
+// the v2 environment.
+func (c *Client) resolveV2Link(link string) string {
+	if strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") {
+		return link
+	}
+
+	return concat(c.EnvironmentV2, link)
+}
+
+// encodeQuery encodes URL values in a stable order without escaping the square brackets used by JSON:API parameter
+// families such as fields[albums] and page[cursor].
+func encodeQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var params []string
+
+	for _, key := range keys {
+		for _, value := range values[key] {
+			params = append(params, concat(key, "=", url.QueryEscape(value)))
+		}
+	}
+
+	return strings.Join(params, "&")
+}
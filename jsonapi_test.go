@@ -0,0 +1,203 @@
+package gotidal
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+func TestResourceData_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	type expected struct {
+		Many  bool
+		Count int
+	}
+
+	tests := []struct {
+		name     string
+		data     string
+		expected expected
+		wantErr  bool
+	}{
+		{
+			"Null",
+			`{"data": null}`,
+			expected{Many: false, Count: 0},
+			false,
+		},
+		{
+			"Single resource",
+			`{"data": {"id": "1", "type": "albums"}}`,
+			expected{Many: false, Count: 1},
+			false,
+		},
+		{
+			"List of resources",
+			`{"data": [{"id": "1", "type": "albums"}, {"id": "2", "type": "albums"}]}`,
+			expected{Many: true, Count: 2},
+			false,
+		},
+		{
+			"Invalid resource",
+			`{"data": "albums"}`,
+			expected{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var doc Document
+
+			err := json.Unmarshal([]byte(tt.data), &doc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResourceData.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if doc.Data.Many != tt.expected.Many {
+				t.Errorf("ResourceData.UnmarshalJSON() Many = %v, want %v", doc.Data.Many, tt.expected.Many)
+			}
+
+			if len(doc.Data.Resources) != tt.expected.Count {
+				t.Errorf("ResourceData.UnmarshalJSON() Count = %v, want %v", len(doc.Data.Resources), tt.expected.Count)
+			}
+		})
+	}
+}
+
+func TestResourceLinkage_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		want int
+	}{
+		{
+			"To-one",
+			`{"data": {"id": "1", "type": "artists"}}`,
+			1,
+		},
+		{
+			"To-many",
+			`{"data": [{"id": "1", "type": "artists"}, {"id": "2", "type": "artists"}]}`,
+			2,
+		},
+		{
+			"Empty to-one",
+			`{"data": null}`,
+			0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var relationship Relationship
+
+			err := json.Unmarshal([]byte(tt.data), &relationship)
+			if err != nil {
+				t.Errorf("ResourceLinkage.UnmarshalJSON() error = %v", err)
+				return
+			}
+
+			if len(relationship.Data) != tt.want {
+				t.Errorf("ResourceLinkage.UnmarshalJSON() = %v, want %v", len(relationship.Data), tt.want)
+			}
+		})
+	}
+}
+
+func TestV2Params_values(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		params V2Params
+		want   string
+	}{
+		{
+			"Country code only",
+			V2Params{},
+			"countryCode=AU",
+		},
+		{
+			"Include and sparse fieldsets",
+			V2Params{
+				Include: []string{"artists", "items"},
+				Fields: map[string][]string{
+					"albums":  {"title", "releaseDate"},
+					"artists": {"name"},
+				},
+			},
+			"countryCode=AU&fields[albums]=title%2CreleaseDate&fields[artists]=name&include=artists%2Citems",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := encodeQuery(tt.params.values(countryCode)); got != tt.want {
+				t.Errorf("V2Params.values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_resolveV2Link(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{EnvironmentV2: environmentV2}
+
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{
+			"Relative link",
+			"/albums?page[cursor]=abc",
+			"https://openapi.tidal.com/v2/albums?page[cursor]=abc",
+		},
+		{
+			"Absolute link",
+			"https://openapi.tidal.com/v2/albums?page[cursor]=abc",
+			"https://openapi.tidal.com/v2/albums?page[cursor]=abc",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := client.resolveV2Link(tt.link); got != tt.want {
+				t.Errorf("Client.resolveV2Link() = %v, want %v", got, tt.want)
+			}
+
+			if _, err := url.Parse(client.resolveV2Link(tt.link)); err != nil {
+				t.Errorf("Client.resolveV2Link() returned an invalid URL: %v", err)
+			}
+		})
+	}
+}
+
+func TestResourceObject_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	resource := ResourceObject{ResourceIdentifier: ResourceIdentifier{ID: "abc", Type: "playlists"}}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	if want := `{"id":"abc","type":"playlists"}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
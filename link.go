@@ -0,0 +1,186 @@
+package gotidal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	LinkTypeAlbum    = "album"
+	LinkTypeArtist   = "artist"
+	LinkTypeTrack    = "track"
+	LinkTypeVideo    = "video"
+	LinkTypePlaylist = "playlist"
+
+	shareURL    = "https://tidal.com/browse/"
+	deepLinkURI = "tidal://"
+)
+
+var ErrUnrecognizedLink = errors.New("the link is not a TIDAL album, artist, track, video or playlist link")
+
+// Link identifies a TIDAL resource by its type and ID.
+type Link struct {
+	// Type is the kind of resource the link points to.
+	// Example: album, artist, track, video, playlist
+	Type string
+	ID   string
+}
+
+// ParseLink returns the resource a TIDAL link points to. It accepts share links such as
+// https://tidal.com/browse/track/51584179, web player links from listen.tidal.com, embed links from embed.tidal.com,
+// tidal:// URIs and links without a scheme.
+//
+// Links to a track within an album, such as https://listen.tidal.com/album/51584178/track/51584179, point to the
+// track.
+func ParseLink(link string) (Link, error) {
+	trimmed := strings.TrimSpace(link)
+	if !strings.Contains(trimmed, "://") {
+		trimmed = concat("https://", trimmed)
+	}
+
+	parsed, err := url.Parse(trimmed)
+	if err != nil {
+		return Link{}, fmt.Errorf("%w: %q", ErrUnrecognizedLink, link)
+	}
+
+	var segments []string
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "tidal":
+		// The resource type is parsed as the host of a tidal:// URI.
+		segments = append([]string{parsed.Host}, pathSegments(parsed.Path)...)
+	case "http", "https":
+		host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+		if host != "tidal.com" && !strings.HasSuffix(host, ".tidal.com") {
+			return Link{}, fmt.Errorf("%w: %q", ErrUnrecognizedLink, link)
+		}
+
+		segments = pathSegments(parsed.Path)
+	default:
+		return Link{}, fmt.Errorf("%w: %q", ErrUnrecognizedLink, link)
+	}
+
+	parsedLink, ok := linkFromSegments(segments)
+	if !ok {
+		return Link{}, fmt.Errorf("%w: %q", ErrUnrecognizedLink, link)
+	}
+
+	return parsedLink, nil
+}
+
+func pathSegments(path string) []string {
+	var segments []string
+
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
+
+// linkFromSegments finds the last resource type and ID pair in a path, skipping prefixes such as browse.
+func linkFromSegments(segments []string) (Link, bool) {
+	var (
+		found Link
+		ok    bool
+	)
+
+	for i := 0; i+1 < len(segments); i++ {
+		linkType, known := linkType(segments[i])
+		if !known || !validLinkID(linkType, segments[i+1]) {
+			continue
+		}
+
+		found, ok = Link{Type: linkType, ID: segments[i+1]}, true
+		i++
+	}
+
+	return found, ok
+}
+
+// linkType normalizes a path segment to a link type. Embed links use the plural form.
+func linkType(segment string) (string, bool) {
+	linkType := strings.TrimSuffix(strings.ToLower(segment), "s")
+
+	switch linkType {
+	case LinkTypeAlbum, LinkTypeArtist, LinkTypeTrack, LinkTypeVideo, LinkTypePlaylist:
+		return linkType, true
+	default:
+		return "", false
+	}
+}
+
+// validLinkID reports whether an ID has the right shape for the link type. Playlists have UUIDs and everything else
+// has numeric IDs.
+func validLinkID(linkType string, id string) bool {
+	for _, char := range id {
+		switch {
+		case char >= '0' && char <= '9':
+		case linkType == LinkTypePlaylist && strings.ContainsRune("-abcdefABCDEF", char):
+		default:
+			return false
+		}
+	}
+
+	return id != ""
+}
+
+// URL returns the canonical share link of the resource.
+func (l Link) URL() string {
+	return concat(shareURL, l.Type, "/", l.ID)
+}
+
+// URI returns the deep link that opens the resource in the TIDAL apps.
+func (l Link) URI() string {
+	return concat(deepLinkURI, l.Type, "/", l.ID)
+}
+
+// String returns the canonical share link of the resource.
+func (l Link) String() string {
+	return l.URL()
+}
+
+// ResolvedLink holds the resource a link points to. Only the field matching the link type is set.
+type ResolvedLink struct {
+	Link
+
+	Album    *Album
+	Artist   *Artist
+	Track    *Track
+	Video    *Video
+	Playlist *Playlist
+}
+
+// Resolve parses a TIDAL link and fetches the album, artist, track, video or playlist it points to.
+func (c *Client) Resolve(ctx context.Context, link string) (*ResolvedLink, error) {
+	parsed, err := ParseLink(link)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := &ResolvedLink{Link: parsed}
+
+	switch parsed.Type {
+	case LinkTypeAlbum:
+		resolved.Album, err = c.GetSingleAlbum(ctx, parsed.ID)
+	case LinkTypeArtist:
+		resolved.Artist, err = c.GetSingleArtist(ctx, parsed.ID)
+	case LinkTypeTrack:
+		resolved.Track, err = c.GetSingleTrack(ctx, parsed.ID)
+	case LinkTypeVideo:
+		resolved.Video, err = c.GetSingleVideo(ctx, parsed.ID)
+	case LinkTypePlaylist:
+		resolved.Playlist, err = c.GetPlaylist(ctx, parsed.ID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resolved, nil
+}
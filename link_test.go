@@ -0,0 +1,127 @@
+package gotidal
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParseLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		link    string
+		want    Link
+		wantErr bool
+	}{
+		{"Share link", "https://tidal.com/browse/track/51584179", Link{LinkTypeTrack, "51584179"}, false},
+		{"Share link with suffix", "https://tidal.com/browse/album/51584178/u", Link{LinkTypeAlbum, "51584178"}, false},
+		{"Short link", "https://tidal.com/artist/11950?utm_source=share", Link{LinkTypeArtist, "11950"}, false},
+		{"No scheme", "www.tidal.com/browse/video/75623239", Link{LinkTypeVideo, "75623239"}, false},
+		{"Web player", "https://listen.tidal.com/album/51584178", Link{LinkTypeAlbum, "51584178"}, false},
+		{
+			"Web player track in album",
+			"https://listen.tidal.com/album/51584178/track/51584179",
+			Link{LinkTypeTrack, "51584179"},
+			false,
+		},
+		{"Embed", "https://embed.tidal.com/tracks/51584179", Link{LinkTypeTrack, "51584179"}, false},
+		{"URI", "tidal://track/51584179", Link{LinkTypeTrack, "51584179"}, false},
+		{
+			"Playlist",
+			"https://tidal.com/browse/playlist/36ea71a8-445e-41a4-82ab-6628c581535d",
+			Link{LinkTypePlaylist, "36ea71a8-445e-41a4-82ab-6628c581535d"},
+			false,
+		},
+		{"Other host", "https://example.com/browse/track/51584179", Link{}, true},
+		{"Unknown type", "https://tidal.com/browse/mix/0123456789abcdef", Link{}, true},
+		{"Non-numeric ID", "https://tidal.com/browse/track/abc", Link{}, true},
+		{"No ID", "https://tidal.com/browse/track", Link{}, true},
+		{"Other scheme", "spotify://track/51584179", Link{}, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseLink(tt.link)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, ErrUnrecognizedLink) {
+				t.Errorf("ParseLink() error = %v, want %v", err, ErrUnrecognizedLink)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLink_URL(t *testing.T) {
+	t.Parallel()
+
+	link := Link{Type: LinkTypeTrack, ID: "51584179"}
+
+	if got, want := link.URL(), "https://tidal.com/browse/track/51584179"; got != want {
+		t.Errorf("Link.URL() = %v, want %v", got, want)
+	}
+
+	if got, want := link.URI(), "tidal://track/51584179"; got != want {
+		t.Errorf("Link.URI() = %v, want %v", got, want)
+	}
+
+	for _, generated := range []string{link.URL(), link.URI()} {
+		if parsed, err := ParseLink(generated); err != nil || parsed != link {
+			t.Errorf("ParseLink(%s) = %v, %v, want %v", generated, parsed, err, link)
+		}
+	}
+}
+
+func TestClient_Resolve(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{
+		httpClient: &mockRoutedHTTPClient{Routes: map[string]string{
+			"/albums/51584178": "testdata/single-album.json",
+			"/artists/5907":    "testdata/single-artist.json",
+			"/tracks/51584179": "testdata/single-track.json",
+			"/videos/75623239": "testdata/single-video.json",
+			"/playlists/36ea71a8-445e-41a4-82ab-6628c581535d": "testdata/single-playlist.json",
+		}},
+		CountryCode: countryCode,
+	}
+
+	tests := []struct {
+		link string
+		id   func(resolved *ResolvedLink) string
+	}{
+		{"https://tidal.com/browse/album/51584178", func(r *ResolvedLink) string { return r.Album.ID }},
+		{"https://tidal.com/browse/artist/5907", func(r *ResolvedLink) string { return r.Artist.ID }},
+		{"tidal://track/51584179", func(r *ResolvedLink) string { return r.Track.ID }},
+		{"https://listen.tidal.com/video/75623239", func(r *ResolvedLink) string { return r.Video.ID }},
+		{
+			"https://tidal.com/browse/playlist/36ea71a8-445e-41a4-82ab-6628c581535d",
+			func(r *ResolvedLink) string { return r.Playlist.ID },
+		},
+	}
+	for _, tt := range tests {
+		resolved, err := client.Resolve(context.Background(), tt.link)
+		if err != nil {
+			t.Errorf("Client.Resolve(%s) error = %v", tt.link, err)
+			continue
+		}
+
+		if got := tt.id(resolved); got != resolved.ID {
+			t.Errorf("Client.Resolve(%s) fetched %v, want %v", tt.link, got, resolved.ID)
+		}
+	}
+
+	_, err := client.Resolve(context.Background(), "https://tidal.com/browse/track/1")
+	if !errors.Is(err, ErrUnexpectedResponseCode) {
+		t.Errorf("Client.Resolve() error = %v, want %v", err, ErrUnexpectedResponseCode)
+	}
+}
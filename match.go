@@ -0,0 +1,594 @@
+package gotidal
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	defaultMinConfidence     = 0.8
+	defaultDurationTolerance = 3
+	defaultMatchSearchLimit  = 10
+
+	// durationWindow is how many seconds beyond the tolerance it takes for the duration score to drop to zero.
+	durationWindow = 30
+
+	// isrcConfidence is the confidence of a candidate with the same ISRC before the other signals are counted.
+	isrcConfidence = 0.5
+
+	// minorVersionScore is the version score of a candidate that only differs by a remaster or a mono or stereo mix.
+	minorVersionScore = 0.75
+
+	titleWeight    = 0.30
+	artistWeight   = 0.25
+	albumWeight    = 0.10
+	durationWeight = 0.15
+	versionWeight  = 0.15
+	explicitWeight = 0.05
+
+	confidencePrecision = 1000
+)
+
+// TrackQuery describes a track from another service to find in the TIDAL catalog. Only the title or the ISRC is
+// required, but every other field that is known improves the match.
+type TrackQuery struct {
+	Title string
+
+	// Artist is one or more artists, such as "Daft Punk feat. Pharrell Williams".
+	Artist string
+
+	Album string
+
+	// Duration is the length of the track in seconds, or zero if it is unknown.
+	Duration int
+
+	ISRC string
+
+	// Explicit is whether the track has explicit lyrics, or nil if it is unknown.
+	Explicit *bool
+}
+
+// MatchOptions controls how candidates are found and scored.
+type MatchOptions struct {
+	// MinConfidence is the confidence a candidate needs to be accepted as the match. The catalog is only searched
+	// when none of the tracks found by ISRC reach it. Defaults to 0.8.
+	MinConfidence float64
+
+	// DurationTolerance is the difference in seconds between the durations that still scores as a full match.
+	// Defaults to 3.
+	DurationTolerance int
+
+	// SearchLimit is the number of search results scored for each search. Defaults to 10.
+	SearchLimit int
+}
+
+func (o MatchOptions) withDefaults() MatchOptions {
+	if o.MinConfidence <= 0 {
+		o.MinConfidence = defaultMinConfidence
+	}
+
+	if o.DurationTolerance <= 0 {
+		o.DurationTolerance = defaultDurationTolerance
+	}
+
+	if o.SearchLimit <= 0 {
+		o.SearchLimit = defaultMatchSearchLimit
+	}
+
+	return o
+}
+
+// MatchCandidate is a TIDAL track scored against a query.
+type MatchCandidate struct {
+	Track Track
+
+	// Confidence is between 0 and 1, where 1 means every known field of the query matches the track.
+	Confidence float64
+
+	// Reasons explain the score, such as "same ISRC" or "duration differs by 12s".
+	Reasons []string
+}
+
+// TrackMatch is the outcome of matching a query against the catalog.
+type TrackMatch struct {
+	Query TrackQuery
+
+	// Candidates holds every track found for the query, best first. Candidates with the same confidence are ordered
+	// by ID, so the ranking is the same every time for the same catalog responses.
+	Candidates []MatchCandidate
+
+	// Match is the best candidate, or nil when no candidate reaches the minimum confidence.
+	Match *MatchCandidate
+}
+
+// MatchTrack finds the TIDAL tracks that best match a track from another service. The tracks with the ISRC of the
+// query are scored first, and when none of them is a confident match the catalog is searched for the artist and
+// title, and then for the title alone if that finds nothing.
+func (c *Client) MatchTrack(ctx context.Context, query TrackQuery, options MatchOptions) (*TrackMatch, error) {
+	options = options.withDefaults()
+
+	isrc, isrcErr := ParseISRC(query.ISRC)
+	if isrcErr != nil && strings.TrimSpace(query.Title) == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	match := &TrackMatch{Query: query}
+	seen := map[string]bool{}
+
+	add := func(tracks []Track) {
+		for _, track := range tracks {
+			if !seen[track.ID] {
+				seen[track.ID] = true
+				match.Candidates = append(match.Candidates, ScoreTrack(query, track, options))
+			}
+		}
+
+		rankCandidates(match.Candidates)
+	}
+
+	if isrcErr == nil {
+		tracks, err := c.lookupISRC(ctx, isrc)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+
+		add(tracks)
+	}
+
+	if !match.confident(options) && strings.TrimSpace(query.Title) != "" {
+		for _, text := range matchSearchQueries(query) {
+			tracks, err := c.searchTracks(ctx, text, options.SearchLimit)
+			if err != nil {
+				return nil, err
+			}
+
+			add(tracks)
+
+			if len(tracks) > 0 {
+				break
+			}
+		}
+	}
+
+	if match.confident(options) {
+		match.Match = &match.Candidates[0]
+	}
+
+	return match, nil
+}
+
+func (m *TrackMatch) confident(options MatchOptions) bool {
+	return len(m.Candidates) > 0 && m.Candidates[0].Confidence >= options.MinConfidence
+}
+
+func (c *Client) searchTracks(ctx context.Context, query string, limit int) ([]Track, error) {
+	results, err := c.Search(ctx, SearchParams{Query: query, Type: SearchTypeTracks, Limit: limit})
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results.Tracks, nil
+}
+
+// matchSearchQueries returns the searches for a query: the first artist with the title and its version, then the
+// title alone.
+func matchSearchQueries(query TrackQuery) []string {
+	base, versions := splitTitle(query.Title)
+	title := strings.TrimSpace(strings.Join(append([]string{base}, versions...), " "))
+
+	var queries []string
+
+	if artists := splitArtists(query.Artist); len(artists) > 0 {
+		queries = append(queries, concat(artists[0], " ", title))
+	}
+
+	return append(queries, title)
+}
+
+func rankCandidates(candidates []MatchCandidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Confidence != candidates[j].Confidence {
+			return candidates[i].Confidence > candidates[j].Confidence
+		}
+
+		return candidates[i].Track.ID < candidates[j].Track.ID
+	})
+}
+
+// ScoreTrack scores how well a track matches a query, without making any requests. The title, artist, album,
+// duration, version and explicitness each contribute to the confidence when they are known for the query, and a
+// shared ISRC raises it further. A shared ISRC is a full match when the ISRC is all the query has to compare.
+func ScoreTrack(query TrackQuery, track Track, options MatchOptions) MatchCandidate {
+	options = options.withDefaults()
+
+	var score matchScore
+
+	queryTitle, queryVersions := splitTitle(query.Title)
+	trackBase, trackVersions := splitTitle(track.Title)
+	trackVersions = mergeVersions(trackVersions, versionTags(track.Version))
+
+	if queryTitle != "" {
+		similarity := textSimilarity(queryTitle, trackBase)
+		score.add(titleWeight, similarity, fmt.Sprintf("title similarity %.2f", similarity))
+
+		version, reason := versionScore(queryVersions, trackVersions)
+		score.add(versionWeight, version, reason)
+	}
+
+	if query.Artist != "" {
+		similarity := artistSimilarity(query.Artist, track.Artists)
+		score.add(artistWeight, similarity, fmt.Sprintf("artist similarity %.2f", similarity))
+	}
+
+	if query.Album != "" && track.Album.Title != "" {
+		queryAlbum, _ := splitTitle(query.Album)
+		trackAlbum, _ := splitTitle(track.Album.Title)
+		similarity := textSimilarity(queryAlbum, trackAlbum)
+		score.add(albumWeight, similarity, fmt.Sprintf("album similarity %.2f", similarity))
+	}
+
+	if query.Duration > 0 && track.Duration > 0 {
+		duration, reason := durationScore(query.Duration, track.Duration, options.DurationTolerance)
+		score.add(durationWeight, duration, reason)
+	}
+
+	if query.Explicit != nil {
+		if *query.Explicit == trackExplicit(track) {
+			score.add(explicitWeight, 1, "same explicitness")
+		} else {
+			score.add(explicitWeight, 0, "explicitness differs")
+		}
+	}
+
+	confidence := score.confidence()
+
+	if isrc, err := ParseISRC(query.ISRC); err == nil {
+		if trackISRC, err := ParseISRC(track.ISRC); err == nil && isrc == trackISRC {
+			confidence = isrcConfidence + (1-isrcConfidence)*confidence
+			if score.weights == 0 {
+				confidence = 1
+			}
+
+			score.reasons = append([]string{"same ISRC"}, score.reasons...)
+		}
+	}
+
+	return MatchCandidate{
+		Track:      track,
+		Confidence: math.Round(confidence*confidencePrecision) / confidencePrecision,
+		Reasons:    score.reasons,
+	}
+}
+
+// matchScore is a weighted average of the signals known for a query.
+type matchScore struct {
+	total   float64
+	weights float64
+	reasons []string
+}
+
+func (s *matchScore) add(weight float64, value float64, reason string) {
+	s.total += weight * value
+	s.weights += weight
+	s.reasons = append(s.reasons, reason)
+}
+
+func (s *matchScore) confidence() float64 {
+	if s.weights == 0 {
+		return 0
+	}
+
+	return s.total / s.weights
+}
+
+func durationScore(queryDuration int, trackDuration int, tolerance int) (float64, string) {
+	difference := queryDuration - trackDuration
+	if difference < 0 {
+		difference = -difference
+	}
+
+	reason := fmt.Sprintf("duration differs by %ds", difference)
+	if difference <= tolerance {
+		return 1, reason
+	}
+
+	return max(0, 1-float64(difference-tolerance)/durationWindow), reason
+}
+
+// versionScore compares the versions of two titles, such as live or remaster. A remaster or a different mix counts
+// as a minor difference, while any other difference does not match.
+func versionScore(queryVersions []string, trackVersions []string) (float64, string) {
+	var differences []string
+
+	for _, version := range mergeVersions(queryVersions, trackVersions) {
+		if containsString(queryVersions, version) != containsString(trackVersions, version) {
+			differences = append(differences, version)
+		}
+	}
+
+	if len(differences) == 0 {
+		return 1, "same version"
+	}
+
+	reason := concat("version differs: ", strings.Join(differences, ", "))
+
+	for _, version := range differences {
+		if version != "remaster" && version != "mono" && version != "stereo" {
+			return 0, reason
+		}
+	}
+
+	return minorVersionScore, reason
+}
+
+// splitTitle returns the normalized title without the parts in brackets, after a dash or after "feat.", and the
+// version tags, such as live or remaster, found in those parts.
+func splitTitle(title string) (string, []string) {
+	var extras []string
+
+	base := title
+
+	for _, brackets := range []string{"()", "[]", "{}"} {
+		for {
+			start := strings.IndexByte(base, brackets[0])
+			end := strings.IndexByte(base, brackets[1])
+
+			if start < 0 || end < start {
+				break
+			}
+
+			extras = append(extras, base[start+1:end])
+			base = concat(base[:start], " ", base[end+1:])
+		}
+	}
+
+	if before, after, found := strings.Cut(base, " - "); found {
+		base = before
+		extras = append(extras, after)
+	}
+
+	words := strings.Fields(normalizeMatchText(foldAccents(base)))
+	for i, word := range words {
+		if word == "feat" || word == "ft" || word == "featuring" {
+			words = words[:i]
+			break
+		}
+	}
+
+	var versions []string
+	for _, extra := range extras {
+		versions = mergeVersions(versions, versionTags(extra))
+	}
+
+	return strings.Join(words, " "), versions
+}
+
+// versionTags returns the version tags found in text, in order and without duplicates.
+func versionTags(text string) []string {
+	var tags []string
+
+	for _, word := range strings.Fields(normalizeMatchText(foldAccents(text))) {
+		tag := ""
+
+		switch word {
+		case "live", "unplugged":
+			tag = "live"
+		case "remaster", "remastered":
+			tag = "remaster"
+		case "acoustic":
+			tag = "acoustic"
+		case "remix":
+			tag = "remix"
+		case "instrumental":
+			tag = "instrumental"
+		case "demo":
+			tag = "demo"
+		case "edit":
+			tag = "edit"
+		case "mono":
+			tag = "mono"
+		case "stereo":
+			tag = "stereo"
+		case "karaoke":
+			tag = "karaoke"
+		case "extended":
+			tag = "extended"
+		}
+
+		if tag != "" && !containsString(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+func mergeVersions(a []string, b []string) []string {
+	merged := append([]string{}, a...)
+
+	for _, version := range b {
+		if !containsString(merged, version) {
+			merged = append(merged, version)
+		}
+	}
+
+	return merged
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// artistSimilarity is the best similarity between any of the artists of the query and any of the artists of the
+// track, or between all of them together.
+func artistSimilarity(queryArtist string, trackArtists []artistResource) float64 {
+	queryNames := append(splitArtists(queryArtist), queryArtist)
+	trackNames := []string{artistNames(trackArtists)}
+
+	for _, artist := range trackArtists {
+		trackNames = append(trackNames, artist.Name)
+	}
+
+	best := 0.0
+
+	for _, queryName := range queryNames {
+		for _, trackName := range trackNames {
+			best = max(best, textSimilarity(
+				normalizeMatchText(foldAccents(queryName)), normalizeMatchText(foldAccents(trackName)),
+			))
+		}
+	}
+
+	return best
+}
+
+// splitArtists splits a list of artists on commas, ampersands, slashes, semicolons and words such as "feat." and
+// "with".
+func splitArtists(artist string) []string {
+	var artists []string
+
+	for _, part := range strings.FieldsFunc(artist, func(r rune) bool {
+		return r == ',' || r == '&' || r == '/' || r == ';'
+	}) {
+		var words []string
+
+		for _, word := range strings.Fields(part) {
+			switch strings.TrimSuffix(strings.ToLower(word), ".") {
+			case "feat", "ft", "featuring", "with", "x", "vs":
+				artists = appendArtist(artists, strings.Join(words, " "))
+				words = nil
+			default:
+				words = append(words, word)
+			}
+		}
+
+		artists = appendArtist(artists, strings.Join(words, " "))
+	}
+
+	return artists
+}
+
+func appendArtist(artists []string, artist string) []string {
+	if artist = strings.TrimSpace(artist); artist == "" {
+		return artists
+	}
+
+	return append(artists, artist)
+}
+
+// textSimilarity compares two normalized strings. It is the higher of their edit distance similarity and the share of
+// words they have in common, so reordered words still score well.
+func textSimilarity(a string, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	if a == "" || b == "" {
+		return 0
+	}
+
+	runesA, runesB := []rune(a), []rune(b)
+	edit := 1 - float64(levenshtein(runesA, runesB))/float64(max(len(runesA), len(runesB)))
+
+	return max(edit, wordOverlap(strings.Fields(a), strings.Fields(b)))
+}
+
+// levenshtein returns the number of single character insertions, deletions and substitutions between a and b.
+func levenshtein(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := range a {
+		current[0] = i + 1
+
+		for j := range b {
+			cost := 1
+			if a[i] == b[j] {
+				cost = 0
+			}
+
+			current[j+1] = min(previous[j+1]+1, current[j]+1, previous[j]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// wordOverlap is the number of words in common divided by the number of distinct words in either list.
+func wordOverlap(a []string, b []string) float64 {
+	words := map[string]int{}
+
+	for _, word := range a {
+		words[word] |= 1
+	}
+
+	for _, word := range b {
+		words[word] |= 2
+	}
+
+	common := 0
+
+	for _, sides := range words {
+		if sides == 3 { // nolint:gomnd // In both lists.
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(words))
+}
+
+// foldAccents replaces accented Latin letters with their unaccented form, so Beyoncé matches Beyonce.
+func foldAccents(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch unicode.ToLower(r) {
+		case 'à', 'á', 'â', 'ã', 'ä', 'å', 'ā':
+			return 'a'
+		case 'ç', 'ć', 'č':
+			return 'c'
+		case 'è', 'é', 'ê', 'ë', 'ē', 'ę', 'ě':
+			return 'e'
+		case 'ì', 'í', 'î', 'ï', 'ī':
+			return 'i'
+		case 'ñ', 'ń', 'ň':
+			return 'n'
+		case 'ò', 'ó', 'ô', 'õ', 'ö', 'ø', 'ō', 'ő':
+			return 'o'
+		case 'ś', 'š', 'ş':
+			return 's'
+		case 'ù', 'ú', 'û', 'ü', 'ū', 'ů', 'ű':
+			return 'u'
+		case 'ý', 'ÿ':
+			return 'y'
+		case 'ź', 'ż', 'ž':
+			return 'z'
+		case 'ł':
+			return 'l'
+		default:
+			return r
+		}
+	}, text)
+}
+
+func trackExplicit(track Track) bool {
+	return containsString(track.Properties.Content, "explicit")
+}
@@ -0,0 +1,237 @@
+package gotidal
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func matchFixtureTracks(t *testing.T) []Track {
+	t.Helper()
+
+	client := &Client{
+		httpClient:  &mockHTTPClient{FilePath: "testdata/search-match.json", StatusCode: 200},
+		CountryCode: countryCode,
+	}
+
+	tracks, err := client.searchTracks(context.Background(), "age of consent", defaultMatchSearchLimit)
+	if err != nil {
+		t.Fatalf("Client.searchTracks() error = %v", err)
+	}
+
+	return tracks
+}
+
+func TestScoreTrack(t *testing.T) {
+	t.Parallel()
+
+	explicit := true
+	tracks := matchFixtureTracks(t)
+
+	tests := []struct {
+		name      string
+		query     TrackQuery
+		wantFirst string
+		wantMin   float64
+		wantMax   float64
+	}{
+		{
+			"Studio recording with punctuation and a slightly different duration",
+			TrackQuery{
+				Title: "Age Of Consent - 2015 Remaster", Artist: "New Order", Album: "Power, Corruption & Lies",
+				Duration: 313,
+			},
+			"51584179", 0.95, 1,
+		},
+		{
+			"Remaster differences are minor",
+			TrackQuery{Title: "Age of Consent", Artist: "New Order", Duration: 315},
+			"51584179", 0.9, 0.99,
+		},
+		{
+			"Live version",
+			TrackQuery{Title: "Age of Consent (Live)", Artist: "New Order", Duration: 340},
+			"100000001", 0.95, 1,
+		},
+		{
+			"Cover with explicit lyrics",
+			TrackQuery{Title: "Age of Consent", Artist: "Covers Band", Explicit: &explicit},
+			"100000002", 0.9, 1,
+		},
+		{
+			"Same ISRC with different metadata",
+			TrackQuery{Title: "Age of Consent (Remastered)", Artist: "Nouvel Ordre", ISRC: "GB-AAP-15-00379"},
+			"51584179", 0.9, 1,
+		},
+		{
+			"ISRC only",
+			TrackQuery{ISRC: "GBAAP1500379"},
+			"51584179", 1, 1,
+		},
+		{
+			"Different song",
+			TrackQuery{Title: "Blue Monday", Artist: "New Order", Duration: 448},
+			"51584179", 0.3, 0.6,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			candidates := make([]MatchCandidate, 0, len(tracks))
+			for _, track := range tracks {
+				candidates = append(candidates, ScoreTrack(tt.query, track, MatchOptions{}))
+			}
+
+			rankCandidates(candidates)
+
+			best := candidates[0]
+			if best.Track.ID != tt.wantFirst {
+				t.Errorf("ScoreTrack() ranked %s first, want %s: %+v", best.Track.ID, tt.wantFirst, candidates)
+			}
+
+			if best.Confidence < tt.wantMin || best.Confidence > tt.wantMax {
+				t.Errorf("ScoreTrack() confidence = %v, want between %v and %v, reasons %q",
+					best.Confidence, tt.wantMin, tt.wantMax, best.Reasons)
+			}
+
+			if len(best.Reasons) == 0 {
+				t.Error("ScoreTrack() returned no reasons")
+			}
+		})
+	}
+}
+
+func TestClient_MatchTrack(t *testing.T) {
+	t.Parallel()
+
+	routes := map[string]string{
+		"/tracks/byIsrc": "testdata/tracks-by-isrc.json",
+		"/search":        "testdata/search-match.json",
+	}
+
+	tests := []struct {
+		name           string
+		query          TrackQuery
+		wantMatch      string
+		wantCandidates int
+		wantSearches   int
+		wantErr        error
+	}{
+		{
+			"Confident ISRC match skips the search",
+			TrackQuery{Title: "King of the World", Artist: "Hawkwind", ISRC: "GBBLY1600675", Duration: 171},
+			"120259174", 2, 0, nil,
+		},
+		{
+			"ISRC match with different metadata falls back to search",
+			TrackQuery{Title: "Age of Consent", Artist: "New Order", ISRC: "GBBLY1600675", Duration: 315},
+			"51584179", 5, 1, nil,
+		},
+		{
+			"Search",
+			TrackQuery{Title: "Age of Consent (Live)", Artist: "New Order feat. Someone"},
+			"100000001", 3, 1, nil,
+		},
+		{
+			"No confident match",
+			TrackQuery{Title: "Blue Monday", Artist: "New Order"},
+			"", 3, 1, nil,
+		},
+		{
+			"ISRC only",
+			TrackQuery{ISRC: "GBBLY1600675"},
+			"120259174", 2, 0, nil,
+		},
+		{
+			"Missing title and ISRC",
+			TrackQuery{Artist: "New Order"},
+			"", 0, 0, ErrMissingRequiredParameters,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			counting := &countingHTTPClient{
+				HTTPClient: &mockRoutedHTTPClient{Routes: routes},
+				counts:     map[string]int{},
+			}
+			client := &Client{httpClient: counting, CountryCode: countryCode}
+
+			match, err := client.MatchTrack(context.Background(), tt.query, MatchOptions{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Client.MatchTrack() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			got := ""
+			if match.Match != nil {
+				got = match.Match.Track.ID
+			}
+
+			if got != tt.wantMatch {
+				t.Errorf("Client.MatchTrack() Match = %q, want %q: %+v", got, tt.wantMatch, match.Candidates)
+			}
+
+			if len(match.Candidates) != tt.wantCandidates {
+				t.Errorf("Client.MatchTrack() returned %d candidates, want %d", len(match.Candidates), tt.wantCandidates)
+			}
+
+			if searches := counting.counts["/search"]; searches != tt.wantSearches {
+				t.Errorf("Client.MatchTrack() searched %d times, want %d", searches, tt.wantSearches)
+			}
+
+			again, err := client.MatchTrack(context.Background(), tt.query, MatchOptions{})
+			if err != nil || !reflect.DeepEqual(again, match) {
+				t.Errorf("Client.MatchTrack() is not deterministic: %+v, want %+v", again, match)
+			}
+		})
+	}
+}
+
+func TestSplitTitle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		title        string
+		wantBase     string
+		wantVersions []string
+	}{
+		{"Age of Consent (2015 Remaster)", "age of consent", []string{"remaster"}},
+		{"Get Lucky (feat. Pharrell Williams) [Radio Edit]", "get lucky", []string{"edit"}},
+		{"Heroes - Live at Wembley / Remastered", "heroes", []string{"live", "remaster"}},
+		{"Café del Mar ft. Someone", "cafe del mar", nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			base, versions := splitTitle(tt.title)
+			if base != tt.wantBase || !reflect.DeepEqual(versions, tt.wantVersions) {
+				t.Errorf("splitTitle() = %q, %q, want %q, %q", base, versions, tt.wantBase, tt.wantVersions)
+			}
+		})
+	}
+}
+
+func TestSplitArtists(t *testing.T) {
+	t.Parallel()
+
+	got := splitArtists("Daft Punk feat. Pharrell Williams & Nile Rodgers, Random/Access")
+	want := []string{"Daft Punk", "Pharrell Williams", "Nile Rodgers", "Random", "Access"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitArtists() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,69 @@
+package gotidal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type contextKey int
+
+const (
+	countryCodeKey contextKey = iota
+	localeKey
+)
+
+// WithCountryCode returns a context that makes requests for the given market instead of the client's CountryCode,
+// so a single client can compare the catalog across countries.
+func WithCountryCode(ctx context.Context, countryCode string) context.Context {
+	return context.WithValue(ctx, countryCodeKey, countryCode)
+}
+
+// WithLocale returns a context that requests localized titles and descriptions in the given locale instead of the
+// client's Locale.
+// Example: en_US, de_DE, ja_JP
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey, locale)
+}
+
+// countryCode returns the upper case country code set on the context with WithCountryCode, falling back to the
+// client's.
+func (c *Client) countryCode(ctx context.Context) string {
+	if countryCode, ok := ctx.Value(countryCodeKey).(string); ok && countryCode != "" {
+		return strings.ToUpper(strings.TrimSpace(countryCode))
+	}
+
+	return strings.ToUpper(strings.TrimSpace(c.CountryCode))
+}
+
+// checkCountryCode validates the country code of a request, so a typo fails with a descriptive error before the
+// request is sent rather than with an opaque API error.
+func (c *Client) checkCountryCode(ctx context.Context) error {
+	countryCode := c.countryCode(ctx)
+	if countryCode == "" || CountryCode(countryCode).Valid() {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q", ErrInvalidCountryCode, countryCode)
+}
+
+// locale returns the locale set on the context with WithLocale, falling back to the client's.
+func (c *Client) locale(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey).(string); ok && locale != "" {
+		return locale
+	}
+
+	return c.Locale
+}
+
+// v2Values returns the query parameters of a v2 request, including the country code and locale for the context.
+func (c *Client) v2Values(ctx context.Context, params V2Params) url.Values {
+	values := params.values(c.countryCode(ctx))
+
+	if locale := c.locale(ctx); locale != "" {
+		values.Set("locale", locale)
+	}
+
+	return values
+}
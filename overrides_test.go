@@ -0,0 +1,106 @@
+package gotidal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestClient_overrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		locale      string
+		wantCountry string
+		wantLocale  string
+	}{
+		{
+			"Client defaults",
+			context.Background(),
+			"",
+			countryCode,
+			"",
+		},
+		{
+			"Client locale",
+			context.Background(),
+			"en_AU",
+			countryCode,
+			"en_AU",
+		},
+		{
+			"Country override",
+			WithCountryCode(context.Background(), "DE"),
+			"",
+			"DE",
+			"",
+		},
+		{
+			"Country and locale overrides",
+			WithLocale(WithCountryCode(context.Background(), "JP"), "ja_JP"),
+			"en_AU",
+			"JP",
+			"ja_JP",
+		},
+		{
+			"Empty override falls back to the client",
+			WithCountryCode(context.Background(), ""),
+			"",
+			countryCode,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var query url.Values
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.Query()
+
+				_, _ = w.Write([]byte(`{"data":[]}`))
+			}))
+			defer server.Close()
+
+			client := &Client{
+				httpClient:    server.Client(),
+				Environment:   server.URL,
+				EnvironmentV2: server.URL,
+				CountryCode:   countryCode,
+				Locale:        tt.locale,
+			}
+
+			_, err := client.request(tt.ctx, http.MethodGet, "/albums", PaginationParams{Limit: 1})
+			if err != nil {
+				t.Fatalf("Client.request() error = %v", err)
+			}
+
+			assertOverrides(t, "Client.request()", query, tt.wantCountry, tt.wantLocale)
+
+			_, err = client.requestV2(tt.ctx, http.MethodGet, "/albums", client.v2Values(tt.ctx, V2Params{}))
+			if err != nil {
+				t.Fatalf("Client.requestV2() error = %v", err)
+			}
+
+			assertOverrides(t, "Client.requestV2()", query, tt.wantCountry, tt.wantLocale)
+		})
+	}
+}
+
+func assertOverrides(t *testing.T, name string, query url.Values, wantCountry string, wantLocale string) {
+	t.Helper()
+
+	if got := query.Get("countryCode"); got != wantCountry {
+		t.Errorf("%s countryCode = %v, want %v", name, got, wantCountry)
+	}
+
+	if got := query.Get("locale"); got != wantLocale {
+		t.Errorf("%s locale = %v, want %v", name, got, wantLocale)
+	}
+}
@@ -0,0 +1,416 @@
+package gotidal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ArtifactTypeTrack = "track"
+	ArtifactTypeVideo = "video"
+)
+
+var ErrUnknownArtifactType = errors.New("the item has an unknown artifact type")
+
+// Playlist represents a user or editorial playlist.
+type Playlist struct {
+	playlistResource `json:"resource"`
+
+	// ETag identifies the version of the playlist. Pass it to the playlist edit functions so changes are rejected
+	// with ErrPlaylistModified if someone else edited the playlist in the meantime.
+	ETag string `json:"-"`
+}
+
+type playlistResource struct {
+	ID             string          `json:"id"`
+	Title          string          `json:"title"`
+	Description    string          `json:"description"`
+	Creator        PlaylistCreator `json:"creator"`
+	Type           string          `json:"type"`
+	Privacy        string          `json:"privacy"`
+	NumberOfTracks int             `json:"numberOfTracks"`
+	NumberOfVideos int             `json:"numberOfVideos"`
+	Duration       int             `json:"duration"`
+	Created        time.Time       `json:"created"`
+	LastUpdated    time.Time       `json:"lastUpdated"`
+	Image          []Image         `json:"image"`
+	TidalURL       string          `json:"tidalUrl"`
+}
+
+// PlaylistCreator represents the owner of a playlist.
+type PlaylistCreator struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PlaylistItem represents an individual track or video in a playlist. Only one of Track or Video is set, depending
+// on the Type of the item. Items that are no longer available have an empty Type and neither Track nor Video set.
+type PlaylistItem struct {
+	Type      string
+	Track     *Track
+	Video     *Video
+	DateAdded time.Time
+}
+
+type playlistItemResult struct {
+	Resource  json.RawMessage `json:"resource"`
+	DateAdded time.Time       `json:"dateAdded"`
+}
+
+type playlistItemResults struct {
+	Data     []PlaylistItem `json:"data"`
+	MetaData ItemMetaData   `json:"metadata"`
+}
+
+type playlistResults struct {
+	Data []Playlist `json:"data"`
+}
+
+// UnmarshalJSON decodes the item resource into a Track or a Video based on its artifact type.
+func (i *PlaylistItem) UnmarshalJSON(data []byte) error {
+	var result playlistItemResult
+
+	err := json.Unmarshal(data, &result)
+	if err != nil {
+		return err // nolint:wrapcheck // Wrapped by the caller.
+	}
+
+	i.DateAdded = result.DateAdded
+
+	// Items that are no longer available in the catalogue are returned without a resource.
+	if len(result.Resource) == 0 || string(result.Resource) == "null" {
+		return nil
+	}
+
+	var artifact struct {
+		ArtifactType string `json:"artifactType"`
+	}
+
+	err = json.Unmarshal(result.Resource, &artifact)
+	if err != nil {
+		return err // nolint:wrapcheck // Wrapped by the caller.
+	}
+
+	i.Type = artifact.ArtifactType
+
+	switch artifact.ArtifactType {
+	case ArtifactTypeTrack:
+		i.Track = &Track{}
+
+		return json.Unmarshal(result.Resource, &i.Track.trackResource) // nolint:wrapcheck // Wrapped by the caller.
+	case ArtifactTypeVideo:
+		i.Video = &Video{}
+
+		return json.Unmarshal(result.Resource, &i.Video.videoResource) // nolint:wrapcheck // Wrapped by the caller.
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownArtifactType, artifact.ArtifactType)
+	}
+}
+
+// GetPlaylist returns a playlist that matches an ID.
+func (c *Client) GetPlaylist(ctx context.Context, id string) (*Playlist, error) {
+	if id == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	response, err := c.send(ctx, http.MethodGet, concat("/playlists/", id), nil, requestOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the playlists endpoint: %w", err)
+	}
+
+	var result Playlist
+
+	err = json.Unmarshal(response.Body, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the playlists response body: %w", err)
+	}
+
+	result.ETag = response.Header.Get("ETag")
+
+	return &result, nil
+}
+
+// GetPlaylistItems returns the tracks and videos of a playlist in playlist order.
+//
+// Unavailable items are kept with an empty Type, so the index of each item in the slice is its position in the
+// playlist, as used by RemovePlaylistItems and MovePlaylistItems.
+//
+// The items endpoint is paginated in the same way as the album items, so subsequent API calls are made until all the
+// items reported by the metadata are returned.
+func (c *Client) GetPlaylistItems(ctx context.Context, id string) ([]PlaylistItem, error) {
+	if id == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	params := PaginationParams{
+		Limit:  paginationLimit,
+		Offset: 0,
+	}
+
+	var items []PlaylistItem
+
+	for {
+		response, err := c.request(ctx, http.MethodGet, concat("/playlists/", id, "/items"), params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to the playlist items endpoint: %w", err)
+		}
+
+		var results playlistItemResults
+
+		err = json.Unmarshal(response, &results)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the playlist items response body: %w", err)
+		}
+
+		items = append(items, results.Data...)
+
+		params.Offset += params.Limit
+
+		if len(results.Data) == 0 || params.Offset >= results.MetaData.Total {
+			break
+		}
+	}
+
+	return items, nil
+}
+
+// GetUserPlaylists returns a paginated list of the playlists owned by a user.
+func (c *Client) GetUserPlaylists(ctx context.Context, userID string, params PaginationParams) ([]Playlist, error) {
+	if userID == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	response, err := c.request(ctx, http.MethodGet, concat("/users/", userID, "/playlists"), params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the user playlists endpoint: %w", err)
+	}
+
+	var results playlistResults
+
+	err = json.Unmarshal(response, &results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the user playlists response body: %w", err)
+	}
+
+	return results.Data, nil
+}
+
+const (
+	PlaylistPrivacyPublic  = "PUBLIC"
+	PlaylistPrivacyPrivate = "PRIVATE"
+
+	// playlistItemsBatchLimit is the maximum number of tracks added to a playlist in a single request.
+	playlistItemsBatchLimit = 50
+)
+
+var ErrPlaylistModified = errors.New("the playlist was modified since the ETag was read")
+
+// CreatePlaylistParams defines the request parameters used to create a playlist.
+type CreatePlaylistParams struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+
+	// Privacy defaults to private when empty.
+	// Example: PUBLIC, PRIVATE
+	Privacy string `json:"privacy,omitempty"`
+}
+
+// UpdatePlaylistParams defines the playlist fields to change. Nil fields are left unchanged.
+type UpdatePlaylistParams struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Privacy     *string `json:"privacy,omitempty"`
+}
+
+// CreatePlaylist creates a playlist owned by the current user.
+func (c *Client) CreatePlaylist(ctx context.Context, params CreatePlaylistParams) (*Playlist, error) {
+	if params.Title == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	response, err := c.send(ctx, http.MethodPost, "/playlists", nil, requestOptions{Body: params})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the create playlist endpoint: %w", err)
+	}
+
+	var result Playlist
+
+	err = json.Unmarshal(response.Body, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the create playlist response body: %w", err)
+	}
+
+	result.ETag = response.Header.Get("ETag")
+
+	return &result, nil
+}
+
+// UpdatePlaylist changes the title, description or privacy of a playlist and returns the new ETag.
+//
+// If etag is not empty the update is only applied if the playlist has not changed since the ETag was read.
+func (c *Client) UpdatePlaylist(
+	ctx context.Context, id string, etag string, params UpdatePlaylistParams,
+) (string, error) {
+	if id == "" {
+		return "", ErrMissingRequiredParameters
+	}
+
+	newETag, err := c.editPlaylist(ctx, http.MethodPatch, concat("/playlists/", id), etag, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to the update playlist endpoint: %w", err)
+	}
+
+	return newETag, nil
+}
+
+// DeletePlaylist deletes a playlist owned by the current user.
+//
+// If etag is not empty the playlist is only deleted if it has not changed since the ETag was read.
+func (c *Client) DeletePlaylist(ctx context.Context, id string, etag string) error {
+	if id == "" {
+		return ErrMissingRequiredParameters
+	}
+
+	_, err := c.editPlaylist(ctx, http.MethodDelete, concat("/playlists/", id), etag, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the delete playlist endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// AddPlaylistTracks appends tracks to the end of a playlist and returns the new ETag.
+//
+// Tracks are added in batches of 50, with each batch using the ETag returned by the previous one. If a batch fails
+// the earlier batches remain in the playlist.
+func (c *Client) AddPlaylistTracks(ctx context.Context, id string, etag string, trackIDs []string) (string, error) {
+	if id == "" || len(trackIDs) == 0 {
+		return "", ErrMissingRequiredParameters
+	}
+
+	type addItemsBody struct {
+		TrackIDs []string `json:"trackIds"`
+	}
+
+	for _, batch := range chunkIDs(trackIDs, playlistItemsBatchLimit) {
+		newETag, err := c.editPlaylist(
+			ctx, http.MethodPost, concat("/playlists/", id, "/items"), etag, addItemsBody{TrackIDs: batch},
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to connect to the playlist items endpoint: %w", err)
+		}
+
+		etag = newETag
+	}
+
+	return etag, nil
+}
+
+// RemovePlaylistItems removes the items at the given zero-based indices of a playlist and returns the new ETag.
+func (c *Client) RemovePlaylistItems(ctx context.Context, id string, etag string, indices []int) (string, error) {
+	if id == "" || len(indices) == 0 {
+		return "", ErrMissingRequiredParameters
+	}
+
+	newETag, err := c.editPlaylist(
+		ctx, http.MethodDelete, concat("/playlists/", id, "/items/", joinIndices(indices)), etag, nil,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to the playlist items endpoint: %w", err)
+	}
+
+	return newETag, nil
+}
+
+// RemovePlaylistTracks removes every occurrence of the given tracks from a playlist and returns the new ETag.
+//
+// The playlist items are fetched to find the indices of the tracks, so an ETag should be passed to make sure the
+// indices still refer to the same tracks when they are removed.
+func (c *Client) RemovePlaylistTracks(ctx context.Context, id string, etag string, trackIDs []string) (string, error) {
+	if id == "" || len(trackIDs) == 0 {
+		return "", ErrMissingRequiredParameters
+	}
+
+	items, err := c.GetPlaylistItems(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	remove := make(map[string]bool, len(trackIDs))
+	for _, trackID := range trackIDs {
+		remove[trackID] = true
+	}
+
+	var indices []int
+
+	// The unavailable items are kept as placeholders, so the index in the slice is the position in the playlist.
+	for index, item := range items {
+		if item.Track != nil && remove[item.Track.ID] {
+			indices = append(indices, index)
+		}
+	}
+
+	if len(indices) == 0 {
+		return etag, nil
+	}
+
+	return c.RemovePlaylistItems(ctx, id, etag, indices)
+}
+
+// MovePlaylistItems moves the items at the given zero-based indices so they start at toIndex, keeping their relative
+// order, and returns the new ETag.
+func (c *Client) MovePlaylistItems(
+	ctx context.Context, id string, etag string, indices []int, toIndex int,
+) (string, error) {
+	if id == "" || len(indices) == 0 {
+		return "", ErrMissingRequiredParameters
+	}
+
+	type moveItemsBody struct {
+		ToIndex int `json:"toIndex"`
+	}
+
+	newETag, err := c.editPlaylist(
+		ctx, http.MethodPost, concat("/playlists/", id, "/items/", joinIndices(indices)), etag,
+		moveItemsBody{ToIndex: toIndex},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to the playlist items endpoint: %w", err)
+	}
+
+	return newETag, nil
+}
+
+// editPlaylist sends a playlist change guarded by an If-Match header and returns the ETag of the updated playlist.
+func (c *Client) editPlaylist(ctx context.Context, method string, path string, etag string, body any) (string, error) {
+	header := http.Header{}
+	if etag != "" {
+		header.Set("If-Match", etag)
+	}
+
+	response, err := c.send(ctx, method, path, nil, requestOptions{Body: body, Header: header})
+	if err != nil {
+		if isPreconditionFailed(err) {
+			return "", fmt.Errorf("%w: %w", ErrPlaylistModified, err)
+		}
+
+		return "", err
+	}
+
+	return response.Header.Get("ETag"), nil
+}
+
+func joinIndices(indices []int) string {
+	values := make([]string, 0, len(indices))
+	for _, index := range indices {
+		values = append(values, strconv.Itoa(index))
+	}
+
+	return strings.Join(values, ",")
+}
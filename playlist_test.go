@@ -0,0 +1,492 @@
+package gotidal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetPlaylist(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		httpClient HTTPClient
+		id         string
+	}
+
+	type expected struct {
+		ID          string
+		Title       string
+		CreatorName string
+		Type        string
+		Tracks      int
+		Videos      int
+		LastUpdated time.Time
+		ImageCount  int
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		wantErr  bool
+	}{
+		{
+			"Missing ID",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/single-playlist.json", StatusCode: http.StatusOK},
+				id:         "",
+			},
+			expected{},
+			true,
+		},
+		{
+			"Token Error",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/401-token-error.json", StatusCode: http.StatusUnauthorized},
+				id:         "36ea71a8-445e-41a4-82ab-6628c581535d",
+			},
+			expected{},
+			true,
+		},
+		{
+			"Single playlist parses correctly",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/single-playlist.json", StatusCode: http.StatusOK},
+				id:         "36ea71a8-445e-41a4-82ab-6628c581535d",
+			},
+			expected{
+				ID:          "36ea71a8-445e-41a4-82ab-6628c581535d",
+				Title:       "New Order Essentials",
+				CreatorName: "TIDAL",
+				Type:        "EDITORIAL",
+				Tracks:      1,
+				Videos:      1,
+				LastUpdated: time.Date(2024, 2, 11, 8, 15, 2, 0, time.UTC),
+				ImageCount:  1,
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{httpClient: tt.args.httpClient, CountryCode: countryCode}
+
+			playlist, err := client.GetPlaylist(context.Background(), tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetPlaylist() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if playlist.ID != tt.expected.ID {
+				t.Errorf("Client.GetPlaylist() ID = %v, want %v", playlist.ID, tt.expected.ID)
+			}
+
+			if playlist.Title != tt.expected.Title {
+				t.Errorf("Client.GetPlaylist() Title = %v, want %v", playlist.Title, tt.expected.Title)
+			}
+
+			if playlist.Creator.Name != tt.expected.CreatorName {
+				t.Errorf("Client.GetPlaylist() Creator = %v, want %v", playlist.Creator.Name, tt.expected.CreatorName)
+			}
+
+			if playlist.Type != tt.expected.Type {
+				t.Errorf("Client.GetPlaylist() Type = %v, want %v", playlist.Type, tt.expected.Type)
+			}
+
+			if playlist.NumberOfTracks != tt.expected.Tracks {
+				t.Errorf("Client.GetPlaylist() NumberOfTracks = %v, want %v", playlist.NumberOfTracks, tt.expected.Tracks)
+			}
+
+			if playlist.NumberOfVideos != tt.expected.Videos {
+				t.Errorf("Client.GetPlaylist() NumberOfVideos = %v, want %v", playlist.NumberOfVideos, tt.expected.Videos)
+			}
+
+			if !playlist.LastUpdated.Equal(tt.expected.LastUpdated) {
+				t.Errorf("Client.GetPlaylist() LastUpdated = %v, want %v", playlist.LastUpdated, tt.expected.LastUpdated)
+			}
+
+			if len(playlist.Image) != tt.expected.ImageCount {
+				t.Errorf("Client.GetPlaylist() Image = %v, want %v", len(playlist.Image), tt.expected.ImageCount)
+			}
+		})
+	}
+}
+
+func TestGetPlaylistItems(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		httpClient HTTPClient
+		id         string
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		wantTypes []string
+		wantErr   bool
+	}{
+		{
+			"Missing ID",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/playlist-items.json", StatusCode: http.StatusOK},
+				id:         "",
+			},
+			nil,
+			true,
+		},
+		{
+			"Invalid JSON",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/invalid-json.json", StatusCode: http.StatusOK},
+				id:         "36ea71a8-445e-41a4-82ab-6628c581535d",
+			},
+			nil,
+			true,
+		},
+		{
+			"Tracks, videos and unavailable items parse in order",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/playlist-items.json", StatusCode: http.StatusMultiStatus},
+				id:         "36ea71a8-445e-41a4-82ab-6628c581535d",
+			},
+			[]string{ArtifactTypeTrack, "", ArtifactTypeVideo},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{httpClient: tt.args.httpClient, CountryCode: countryCode}
+
+			items, err := client.GetPlaylistItems(context.Background(), tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetPlaylistItems() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(items) != len(tt.wantTypes) {
+				t.Fatalf("Client.GetPlaylistItems() count = %v, want %v", len(items), len(tt.wantTypes))
+			}
+
+			for i, item := range items {
+				if item.Type != tt.wantTypes[i] {
+					t.Errorf("Client.GetPlaylistItems() Type[%d] = %v, want %v", i, item.Type, tt.wantTypes[i])
+				}
+			}
+
+			if items[0].Track == nil || items[0].Track.ISRC != "GBAAP1500379" {
+				t.Errorf("Client.GetPlaylistItems() Track = %+v", items[0].Track)
+			}
+
+			if items[1].Track != nil || items[1].Video != nil {
+				t.Errorf("Client.GetPlaylistItems() unavailable item = %+v", items[1])
+			}
+
+			if items[2].Video == nil || items[2].Video.Title != "Blue Monday" {
+				t.Errorf("Client.GetPlaylistItems() Video = %+v", items[2].Video)
+			}
+
+			wantAdded := time.Date(2016, 3, 4, 10, 22, 1, 0, time.UTC)
+			if !items[0].DateAdded.Equal(wantAdded) {
+				t.Errorf("Client.GetPlaylistItems() DateAdded = %v, want %v", items[0].DateAdded, wantAdded)
+			}
+		})
+	}
+}
+
+func TestGetUserPlaylists(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		httpClient HTTPClient
+		userID     string
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		wantTitles []string
+		wantErr    bool
+	}{
+		{
+			"Missing user ID",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/user-playlists.json", StatusCode: http.StatusOK},
+				userID:     "",
+			},
+			nil,
+			true,
+		},
+		{
+			"User playlists parse correctly",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/user-playlists.json", StatusCode: http.StatusOK},
+				userID:     "123456",
+			},
+			[]string{"Road Trip", "Focus"},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{httpClient: tt.args.httpClient, CountryCode: countryCode}
+
+			playlists, err := client.GetUserPlaylists(context.Background(), tt.args.userID, PaginationParams{Limit: 10})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetUserPlaylists() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if len(playlists) != len(tt.wantTitles) {
+				t.Fatalf("Client.GetUserPlaylists() count = %v, want %v", len(playlists), len(tt.wantTitles))
+			}
+
+			for i, playlist := range playlists {
+				if playlist.Title != tt.wantTitles[i] {
+					t.Errorf("Client.GetUserPlaylists() Title[%d] = %v, want %v", i, playlist.Title, tt.wantTitles[i])
+				}
+			}
+		})
+	}
+}
+
+// fakePlaylistServer simulates the playlist write endpoints for a single playlist, including ETag checks.
+type fakePlaylistServer struct {
+	mu       sync.Mutex
+	title    string
+	trackIDs []string
+	version  int
+	requests int
+}
+
+func (s *fakePlaylistServer) etag() string {
+	return fmt.Sprintf(`"%d"`, s.version)
+}
+
+func (s *fakePlaylistServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+
+	const itemsPath = "/playlists/abc/items"
+
+	if r.Method == http.MethodGet && r.URL.Path == itemsPath {
+		s.writeItems(w)
+		return
+	}
+
+	if r.Method != http.MethodPost || r.URL.Path != "/playlists" {
+		if match := r.Header.Get("If-Match"); match != "" && match != s.etag() {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+	}
+
+	var body struct {
+		Title    *string  `json:"title"`
+		TrackIDs []string `json:"trackIds"`
+		ToIndex  int      `json:"toIndex"`
+	}
+
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	status := http.StatusOK
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/playlists":
+		s.title = *body.Title
+		status = http.StatusCreated
+	case r.Method == http.MethodPatch && r.URL.Path == "/playlists/abc":
+		s.title = *body.Title
+	case r.Method == http.MethodDelete && r.URL.Path == "/playlists/abc":
+		status = http.StatusNoContent
+	case r.Method == http.MethodPost && r.URL.Path == itemsPath:
+		s.trackIDs = append(s.trackIDs, body.TrackIDs...)
+	case strings.HasPrefix(r.URL.Path, concat(itemsPath, "/")):
+		s.editItems(r.Method, strings.TrimPrefix(r.URL.Path, concat(itemsPath, "/")), body.ToIndex)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	s.version++
+	w.Header().Set("ETag", s.etag())
+	w.WriteHeader(status)
+
+	if status == http.StatusCreated {
+		_, _ = fmt.Fprintf(w, `{"resource": {"id": "abc", "title": %q}}`, s.title)
+	}
+}
+
+func (s *fakePlaylistServer) editItems(method string, indexList string, toIndex int) {
+	selected := map[int]bool{}
+
+	for _, value := range strings.Split(indexList, ",") {
+		index, _ := strconv.Atoi(value)
+		selected[index] = true
+	}
+
+	var kept, moved []string
+
+	for index, trackID := range s.trackIDs {
+		if selected[index] {
+			moved = append(moved, trackID)
+		} else {
+			kept = append(kept, trackID)
+		}
+	}
+
+	if method == http.MethodDelete {
+		s.trackIDs = kept
+		return
+	}
+
+	toIndex = min(toIndex, len(kept))
+	s.trackIDs = append(append(append([]string{}, kept[:toIndex]...), moved...), kept[toIndex:]...)
+}
+
+// writeItems lists the playlist tracks, with an empty track ID standing for an item that is no longer available.
+func (s *fakePlaylistServer) writeItems(w http.ResponseWriter) {
+	var items []string
+
+	for _, trackID := range s.trackIDs {
+		if trackID == "" {
+			items = append(items, `{"resource": null}`)
+			continue
+		}
+
+		items = append(items, fmt.Sprintf(`{"resource": {"artifactType": "track", "id": %q}}`, trackID))
+	}
+
+	_, _ = fmt.Fprintf(w, `{"data": [%s], "metadata": {"total": %d}}`, strings.Join(items, ","), len(items))
+}
+
+func TestPlaylistWrites(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fake := &fakePlaylistServer{}
+
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client(), Environment: server.URL, CountryCode: countryCode}
+
+	playlist, err := client.CreatePlaylist(ctx, CreatePlaylistParams{Title: "Road Trip"})
+	if err != nil {
+		t.Fatalf("Client.CreatePlaylist() error = %v", err)
+	}
+
+	if playlist.ID != "abc" || playlist.Title != "Road Trip" || playlist.ETag != `"1"` {
+		t.Errorf("Client.CreatePlaylist() = %+v", playlist)
+	}
+
+	title := "Long Road Trip"
+
+	etag, err := client.UpdatePlaylist(ctx, "abc", playlist.ETag, UpdatePlaylistParams{Title: &title})
+	if err != nil {
+		t.Fatalf("Client.UpdatePlaylist() error = %v", err)
+	}
+
+	_, err = client.UpdatePlaylist(ctx, "abc", playlist.ETag, UpdatePlaylistParams{Title: &title})
+	if !errors.Is(err, ErrPlaylistModified) {
+		t.Errorf("Client.UpdatePlaylist() with a stale ETag error = %v, want %v", err, ErrPlaylistModified)
+	}
+
+	trackIDs := make([]string, 0, playlistItemsBatchLimit+2)
+	for i := 0; i < playlistItemsBatchLimit+2; i++ {
+		trackIDs = append(trackIDs, strconv.Itoa(i))
+	}
+
+	requests := fake.requests
+
+	etag, err = client.AddPlaylistTracks(ctx, "abc", etag, trackIDs)
+	if err != nil {
+		t.Fatalf("Client.AddPlaylistTracks() error = %v", err)
+	}
+
+	if batches := fake.requests - requests; batches != 2 {
+		t.Errorf("Client.AddPlaylistTracks() made %d requests, want 2", batches)
+	}
+
+	etag, err = client.RemovePlaylistTracks(ctx, "abc", etag, trackIDs[2:playlistItemsBatchLimit])
+	if err != nil {
+		t.Fatalf("Client.RemovePlaylistTracks() error = %v", err)
+	}
+
+	etag, err = client.RemovePlaylistItems(ctx, "abc", etag, []int{0})
+	if err != nil {
+		t.Fatalf("Client.RemovePlaylistItems() error = %v", err)
+	}
+
+	etag, err = client.MovePlaylistItems(ctx, "abc", etag, []int{2}, 0)
+	if err != nil {
+		t.Fatalf("Client.MovePlaylistItems() error = %v", err)
+	}
+
+	want := []string{"51", "1", "50"}
+	if !reflect.DeepEqual(fake.trackIDs, want) {
+		t.Errorf("playlist tracks = %v, want %v", fake.trackIDs, want)
+	}
+
+	err = client.DeletePlaylist(ctx, "abc", etag)
+	if err != nil {
+		t.Errorf("Client.DeletePlaylist() error = %v", err)
+	}
+
+	_, err = client.AddPlaylistTracks(ctx, "abc", etag, nil)
+	if !errors.Is(err, ErrMissingRequiredParameters) {
+		t.Errorf("Client.AddPlaylistTracks() error = %v, want %v", err, ErrMissingRequiredParameters)
+	}
+}
+
+func TestClient_RemovePlaylistTracksAfterUnavailableItem(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakePlaylistServer{trackIDs: []string{"1", "", "2", "3", "2"}}
+
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client(), Environment: server.URL, CountryCode: countryCode}
+
+	_, err := client.RemovePlaylistTracks(context.Background(), "abc", fake.etag(), []string{"2"})
+	if err != nil {
+		t.Fatalf("Client.RemovePlaylistTracks() error = %v", err)
+	}
+
+	want := []string{"1", "", "3"}
+	if !reflect.DeepEqual(fake.trackIDs, want) {
+		t.Errorf("playlist tracks = %q, want %q", fake.trackIDs, want)
+	}
+}
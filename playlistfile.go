@@ -0,0 +1,491 @@
+package gotidal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	MatchedByLink   = "link"
+	MatchedByISRC   = "isrc"
+	MatchedBySearch = "search"
+
+	isrcURNPrefix = "urn:isrc:"
+
+	millisecondsPerSecond = 1000
+)
+
+// PlaylistFile is a playlist read from an M3U8, XSPF or JSPF file.
+type PlaylistFile struct {
+	Title   string
+	Entries []PlaylistEntry
+}
+
+// PlaylistEntry is a track in a playlist file. Files written by other systems rarely fill in every field.
+type PlaylistEntry struct {
+	Title  string
+	Artist string
+	Album  string
+
+	// Duration is the length of the track in seconds, or zero if it is unknown.
+	Duration int
+
+	ISRC string
+
+	// Location is the URL or path of the track, such as https://tidal.com/browse/track/51584179.
+	Location string
+}
+
+// NewPlaylistEntry returns the playlist entry written for a track. The location is the TIDAL URL of the track.
+func NewPlaylistEntry(track Track) PlaylistEntry {
+	location := track.TidalURL
+	if location == "" {
+		location = Link{Type: LinkTypeTrack, ID: track.ID}.URL()
+	}
+
+	return PlaylistEntry{
+		Title:    trackTitle(track),
+		Artist:   artistNames(track.Artists),
+		Album:    track.Album.Title,
+		Duration: track.Duration,
+		ISRC:     track.ISRC,
+		Location: location,
+	}
+}
+
+// WriteM3U8 writes the tracks as an extended M3U playlist in UTF-8. Each track has an #EXTINF line with its duration,
+// artists and title, an #EXTALB line with its album and an #EXTISRC line with its ISRC, followed by its TIDAL URL.
+func WriteM3U8(w io.Writer, title string, tracks []Track) error {
+	var playlist strings.Builder
+
+	playlist.WriteString("#EXTM3U\n")
+
+	if title != "" {
+		fmt.Fprintf(&playlist, "#PLAYLIST:%s\n", m3uEscape(title))
+	}
+
+	for _, track := range tracks {
+		entry := NewPlaylistEntry(track)
+
+		fmt.Fprintf(&playlist, "#EXTINF:%d,%s - %s\n", entry.Duration, m3uEscape(entry.Artist), m3uEscape(entry.Title))
+
+		if entry.Album != "" {
+			fmt.Fprintf(&playlist, "#EXTALB:%s\n", m3uEscape(entry.Album))
+		}
+
+		if entry.ISRC != "" {
+			fmt.Fprintf(&playlist, "#EXTISRC:%s\n", entry.ISRC)
+		}
+
+		fmt.Fprintf(&playlist, "%s\n", entry.Location)
+	}
+
+	_, err := io.WriteString(w, playlist.String())
+	if err != nil {
+		return fmt.Errorf("failed to write the M3U8 playlist: %w", err)
+	}
+
+	return nil
+}
+
+// ReadM3U8 reads an M3U or extended M3U playlist. Comments and directives other than #PLAYLIST, #EXTINF, #EXTALB and
+// #EXTISRC are ignored, so plain lists of paths or URLs are read as entries with only a location.
+func ReadM3U8(r io.Reader) (*PlaylistFile, error) {
+	playlist := &PlaylistFile{}
+
+	var entry PlaylistEntry
+
+	scanner := bufio.NewScanner(r)
+
+	for first := true; scanner.Scan(); first = false {
+		line := strings.TrimSpace(scanner.Text())
+		if first {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		directive, value, _ := strings.Cut(line, ":")
+
+		switch {
+		case line == "":
+		case directive == "#PLAYLIST":
+			playlist.Title = strings.TrimSpace(value)
+		case directive == "#EXTINF":
+			entry.Duration, entry.Artist, entry.Title = parseEXTINF(value)
+		case directive == "#EXTALB":
+			entry.Album = strings.TrimSpace(value)
+		case directive == "#EXTISRC":
+			entry.ISRC = strings.TrimSpace(value)
+		case strings.HasPrefix(line, "#"):
+		default:
+			entry.Location = line
+			playlist.Entries = append(playlist.Entries, entry)
+			entry = PlaylistEntry{}
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the M3U8 playlist: %w", err)
+	}
+
+	return playlist, nil
+}
+
+// parseEXTINF parses the value of an #EXTINF directive, such as 315,New Order - Age of Consent. Attributes between the
+// duration and the comma, such as tvg-id="1", are skipped.
+func parseEXTINF(value string) (int, string, string) {
+	quoted := false
+	comma := -1
+
+	for i, r := range value {
+		if r == '"' {
+			quoted = !quoted
+		}
+
+		if r == ',' && !quoted {
+			comma = i
+			break
+		}
+	}
+
+	if comma < 0 {
+		return 0, "", strings.TrimSpace(value)
+	}
+
+	fields := strings.Fields(value[:comma])
+
+	duration := 0
+	if len(fields) > 0 {
+		duration, _ = strconv.Atoi(fields[0])
+	}
+
+	// Unknown durations are written as -1.
+	duration = max(duration, 0)
+
+	artist, title, found := strings.Cut(strings.TrimSpace(value[comma+1:]), " - ")
+	if !found {
+		return duration, "", artist
+	}
+
+	return duration, strings.TrimSpace(artist), strings.TrimSpace(title)
+}
+
+// m3uEscape replaces line breaks, which would end a directive early.
+func m3uEscape(text string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(text)
+}
+
+type xspfPlaylist struct {
+	XMLName xml.Name    `xml:"http://xspf.org/ns/0/ playlist"`
+	Version string      `xml:"version,attr"`
+	Title   string      `xml:"title,omitempty"`
+	Tracks  []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Locations   []string `xml:"location"`
+	Identifiers []string `xml:"identifier"`
+	Title       string   `xml:"title,omitempty"`
+	Creator     string   `xml:"creator,omitempty"`
+	Album       string   `xml:"album,omitempty"`
+	Duration    int      `xml:"duration,omitempty"`
+}
+
+// WriteXSPF writes the tracks as an XSPF playlist. The TIDAL URL of each track is both its location and an
+// identifier, and its ISRC is an urn:isrc: identifier.
+// See: https://xspf.org/spec
+func WriteXSPF(w io.Writer, title string, tracks []Track) error {
+	playlist := xspfPlaylist{Version: "1", Title: title, Tracks: make([]xspfTrack, 0, len(tracks))}
+
+	for _, track := range tracks {
+		entry := NewPlaylistEntry(track)
+
+		playlist.Tracks = append(playlist.Tracks, xspfTrack{
+			Locations:   []string{entry.Location},
+			Identifiers: playlistIdentifiers(entry),
+			Title:       entry.Title,
+			Creator:     entry.Artist,
+			Album:       entry.Album,
+			Duration:    entry.Duration * millisecondsPerSecond,
+		})
+	}
+
+	data, err := xml.MarshalIndent(playlist, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the XSPF playlist: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, data)
+	if err != nil {
+		return fmt.Errorf("failed to write the XSPF playlist: %w", err)
+	}
+
+	return nil
+}
+
+// ReadXSPF reads an XSPF playlist.
+func ReadXSPF(r io.Reader) (*PlaylistFile, error) {
+	var playlist xspfPlaylist
+
+	err := xml.NewDecoder(r).Decode(&playlist)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the XSPF playlist: %w", err)
+	}
+
+	file := &PlaylistFile{
+		Title:   strings.TrimSpace(playlist.Title),
+		Entries: make([]PlaylistEntry, 0, len(playlist.Tracks)),
+	}
+
+	for _, track := range playlist.Tracks {
+		file.Entries = append(file.Entries, newPlaylistFileEntry(
+			track.Title, track.Creator, track.Album, track.Duration, track.Locations, track.Identifiers,
+		))
+	}
+
+	return file, nil
+}
+
+type jspfDocument struct {
+	Playlist jspfPlaylist `json:"playlist"`
+}
+
+type jspfPlaylist struct {
+	Title  string      `json:"title,omitempty"`
+	Tracks []jspfTrack `json:"track"`
+}
+
+type jspfTrack struct {
+	Locations   jspfStrings `json:"location,omitempty"`
+	Identifiers jspfStrings `json:"identifier,omitempty"`
+	Title       string      `json:"title,omitempty"`
+	Creator     string      `json:"creator,omitempty"`
+	Album       string      `json:"album,omitempty"`
+	Duration    int         `json:"duration,omitempty"`
+}
+
+// jspfStrings is a list of URIs, which some JSPF writers give as a single string.
+type jspfStrings []string
+
+func (s *jspfStrings) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		var value string
+
+		err := json.Unmarshal(data, &value)
+		if err != nil {
+			return err // nolint:wrapcheck // Wrapped by the caller.
+		}
+
+		*s = jspfStrings{value}
+
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]string)(s)) // nolint:wrapcheck // Wrapped by the caller.
+}
+
+// WriteJSPF writes the tracks as a JSPF playlist, the JSON form of XSPF, with the same fields as WriteXSPF.
+// See: https://xspf.org/jspf
+func WriteJSPF(w io.Writer, title string, tracks []Track) error {
+	document := jspfDocument{Playlist: jspfPlaylist{Title: title, Tracks: make([]jspfTrack, 0, len(tracks))}}
+
+	for _, track := range tracks {
+		entry := NewPlaylistEntry(track)
+
+		document.Playlist.Tracks = append(document.Playlist.Tracks, jspfTrack{
+			Locations:   jspfStrings{entry.Location},
+			Identifiers: playlistIdentifiers(entry),
+			Title:       entry.Title,
+			Creator:     entry.Artist,
+			Album:       entry.Album,
+			Duration:    entry.Duration * millisecondsPerSecond,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(document)
+	if err != nil {
+		return fmt.Errorf("failed to write the JSPF playlist: %w", err)
+	}
+
+	return nil
+}
+
+// ReadJSPF reads a JSPF playlist.
+func ReadJSPF(r io.Reader) (*PlaylistFile, error) {
+	var document jspfDocument
+
+	err := json.NewDecoder(r).Decode(&document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the JSPF playlist: %w", err)
+	}
+
+	playlist := document.Playlist
+	file := &PlaylistFile{
+		Title:   strings.TrimSpace(playlist.Title),
+		Entries: make([]PlaylistEntry, 0, len(playlist.Tracks)),
+	}
+
+	for _, track := range playlist.Tracks {
+		file.Entries = append(file.Entries, newPlaylistFileEntry(
+			track.Title, track.Creator, track.Album, track.Duration, track.Locations, track.Identifiers,
+		))
+	}
+
+	return file, nil
+}
+
+// playlistIdentifiers returns the XSPF identifiers of an entry: its TIDAL URL and its ISRC as an URN.
+func playlistIdentifiers(entry PlaylistEntry) []string {
+	identifiers := []string{entry.Location}
+	if entry.ISRC != "" {
+		identifiers = append(identifiers, concat(isrcURNPrefix, entry.ISRC))
+	}
+
+	return identifiers
+}
+
+// newPlaylistFileEntry returns the entry for an XSPF or JSPF track. The ISRC is taken from an urn:isrc: or isrc:
+// identifier, and a TIDAL identifier is used as the location when the track has none.
+func newPlaylistFileEntry(
+	title, creator, album string, durationMs int, locations []string, identifiers []string,
+) PlaylistEntry {
+	entry := PlaylistEntry{
+		Title:    strings.TrimSpace(title),
+		Artist:   strings.TrimSpace(creator),
+		Album:    strings.TrimSpace(album),
+		Duration: (max(durationMs, 0) + millisecondsPerSecond/2) / millisecondsPerSecond, // nolint:gomnd // Rounding.
+	}
+
+	if len(locations) > 0 {
+		entry.Location = strings.TrimSpace(locations[0])
+	}
+
+	for _, identifier := range identifiers {
+		identifier = strings.TrimSpace(identifier)
+		lower := strings.ToLower(identifier)
+
+		switch {
+		case strings.HasPrefix(lower, isrcURNPrefix):
+			entry.ISRC = identifier[len(isrcURNPrefix):]
+		case strings.HasPrefix(lower, "isrc:"):
+			entry.ISRC = identifier[len("isrc:"):]
+		case entry.Location == "":
+			if _, err := ParseLink(identifier); err == nil {
+				entry.Location = identifier
+			}
+		}
+	}
+
+	return entry
+}
+
+// PlaylistEntryMatch is the TIDAL track found for a playlist entry.
+type PlaylistEntryMatch struct {
+	Entry PlaylistEntry
+
+	// Track is nil when no track was found for the entry.
+	Track *Track
+
+	// MatchedBy is how the track was found: MatchedByLink, MatchedByISRC or MatchedBySearch.
+	MatchedBy string
+
+	// Err is set when the entry could not be looked up.
+	Err error
+}
+
+// ResolvePlaylistEntries finds the TIDAL track for each playlist entry, in the order of the entries. An entry whose
+// location is a TIDAL track link resolves to that track. Otherwise the entry is matched with MatchTrack, by its ISRC
+// and then by searching for its artist and title, and the best track is only accepted when it reaches the default
+// minimum confidence.
+func (c *Client) ResolvePlaylistEntries(
+	ctx context.Context, entries []PlaylistEntry, options ResolveOptions,
+) ([]PlaylistEntryMatch, error) {
+	matches := make([]PlaylistEntryMatch, len(entries))
+
+	var (
+		mu   sync.Mutex
+		done int
+	)
+
+	runLimited(ctx, len(entries), options.Concurrency, options.Interval, func(i int, err error) {
+		matches[i] = PlaylistEntryMatch{Entry: entries[i]}
+
+		if err == nil {
+			matches[i].Track, matches[i].MatchedBy, err = c.resolvePlaylistEntry(ctx, entries[i])
+		}
+
+		matches[i].Err = err
+
+		if options.Progress != nil {
+			mu.Lock()
+			defer mu.Unlock()
+
+			done++
+			options.Progress(done, len(entries))
+		}
+	})
+
+	if err := ctx.Err(); err != nil {
+		return matches, fmt.Errorf("playlist lookup stopped: %w", err)
+	}
+
+	return matches, nil
+}
+
+func (c *Client) resolvePlaylistEntry(ctx context.Context, entry PlaylistEntry) (*Track, string, error) {
+	if link, err := ParseLink(entry.Location); err == nil && link.Type == LinkTypeTrack {
+		track, err := c.GetSingleTrack(ctx, link.ID)
+		if err == nil {
+			return track, MatchedByLink, nil
+		}
+
+		if !isNotFound(err) {
+			return nil, "", err
+		}
+	}
+
+	isrc, isrcErr := ParseISRC(entry.ISRC)
+	if isrcErr != nil && strings.TrimSpace(entry.Title) == "" {
+		return nil, "", nil
+	}
+
+	match, err := c.MatchTrack(ctx, TrackQuery{
+		Title:    entry.Title,
+		Artist:   entry.Artist,
+		Album:    entry.Album,
+		Duration: entry.Duration,
+		ISRC:     entry.ISRC,
+	}, MatchOptions{})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if match.Match == nil {
+		return nil, "", nil
+	}
+
+	if trackISRC, err := ParseISRC(match.Match.Track.ISRC); isrcErr == nil && err == nil && trackISRC == isrc {
+		return &match.Match.Track, MatchedByISRC, nil
+	}
+
+	return &match.Match.Track, MatchedBySearch, nil
+}
+
+// normalizeMatchText lower cases text and reduces it to letters and digits separated by single spaces.
+func normalizeMatchText(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
@@ -0,0 +1,216 @@
+package gotidal
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlaylistFileRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{
+		httpClient:  &mockHTTPClient{FilePath: "testdata/tracks-by-isrc.json", StatusCode: 200},
+		CountryCode: countryCode,
+	}
+
+	tracks, err := client.GetTracksByISRC(context.Background(), "GBBLY1600675", PaginationParams{})
+	if err != nil {
+		t.Fatalf("Client.GetTracksByISRC() error = %v", err)
+	}
+
+	want := &PlaylistFile{Title: "Road trip"}
+	for _, track := range tracks {
+		want.Entries = append(want.Entries, NewPlaylistEntry(track))
+	}
+
+	tests := []struct {
+		name  string
+		write func(w io.Writer, title string, tracks []Track) error
+		read  func(r io.Reader) (*PlaylistFile, error)
+	}{
+		{"M3U8", WriteM3U8, ReadM3U8},
+		{"XSPF", WriteXSPF, ReadXSPF},
+		{"JSPF", WriteJSPF, ReadJSPF},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buffer bytes.Buffer
+
+			err := tt.write(&buffer, "Road trip", tracks)
+			if err != nil {
+				t.Fatalf("Write%s() error = %v", tt.name, err)
+			}
+
+			for _, field := range []string{"King of the World", "Hawkwind", "The Machine Stops", "GBBLY1600675"} {
+				if !strings.Contains(buffer.String(), field) {
+					t.Errorf("Write%s() output does not contain %q", tt.name, field)
+				}
+			}
+
+			got, err := tt.read(&buffer)
+			if err != nil {
+				t.Fatalf("Read%s() error = %v", tt.name, err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Read%s() = %+v, want %+v", tt.name, got, want)
+			}
+		})
+	}
+}
+
+func TestReadPlaylistFiles(t *testing.T) {
+	t.Parallel()
+
+	tidalEntry := PlaylistEntry{
+		Title:    "Age of Consent (2015 Remaster)",
+		Artist:   "New Order",
+		Album:    "Power Corruption and Lies",
+		Duration: 315,
+		Location: "https://tidal.com/browse/track/51584179",
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		read     func(r io.Reader) (*PlaylistFile, error)
+		want     []PlaylistEntry
+	}{
+		{
+			"M3U8 with attributes, unknown durations and plain paths",
+			"testdata/playlist.m3u8",
+			ReadM3U8,
+			[]PlaylistEntry{
+				tidalEntry,
+				{Title: "King of the World", ISRC: "GBBLY1600675", Location: "/music/Hawkwind/King of the World.flac"},
+				{Location: "/music/untagged.mp3"},
+			},
+		},
+		{
+			"XSPF with a TIDAL identifier and an ISRC identifier",
+			"testdata/playlist.xspf",
+			ReadXSPF,
+			[]PlaylistEntry{
+				tidalEntry,
+				{
+					Title:    "King of the World",
+					Artist:   "Hawkwind",
+					ISRC:     "GBBLY1600675",
+					Location: "file:///music/Hawkwind/King%20of%20the%20World.flac",
+				},
+			},
+		},
+		{
+			"JSPF with a single string identifier",
+			"testdata/playlist.jspf",
+			ReadJSPF,
+			[]PlaylistEntry{
+				tidalEntry,
+				{
+					Title:    "King of the World",
+					Artist:   "Hawkwind",
+					ISRC:     "GBBLY1600675",
+					Location: "file:///music/Hawkwind/King%20of%20the%20World.flac",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			file, err := os.Open(tt.filePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			got, err := tt.read(file)
+			if err != nil {
+				t.Fatalf("read() error = %v", err)
+			}
+
+			if got.Title != "Road trip" {
+				t.Errorf("read() Title = %q, want Road trip", got.Title)
+			}
+
+			if !reflect.DeepEqual(got.Entries, tt.want) {
+				t.Errorf("read() Entries = %+v, want %+v", got.Entries, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ResolvePlaylistEntries(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{
+		httpClient: &mockRoutedHTTPClient{Routes: map[string]string{
+			"/tracks/51584179": "testdata/single-track.json",
+			"/tracks/byIsrc":   "testdata/tracks-by-isrc.json",
+			"/search":          "testdata/search-tracks.json",
+		}},
+		CountryCode: countryCode,
+	}
+
+	entries := []PlaylistEntry{
+		{Title: "Something else", Location: "https://listen.tidal.com/album/51584178/track/51584179"},
+		{Title: "King of the World", Artist: "Hawkwind", ISRC: "gb-bly-16-00675"},
+		{Title: "Age Of Consent - 2015 Remaster", Artist: "Joy Division, New Order"},
+		{Title: "Age of Consent", Artist: "Order"},
+		{Title: "Age of Consent (Live)", Artist: "New Order"},
+		{Location: "/music/untagged.mp3"},
+	}
+
+	var progress int
+
+	matches, err := client.ResolvePlaylistEntries(context.Background(), entries, ResolveOptions{
+		Progress: func(done int, _ int) { progress = done },
+	})
+	if err != nil {
+		t.Fatalf("Client.ResolvePlaylistEntries() error = %v", err)
+	}
+
+	want := []struct {
+		id        string
+		matchedBy string
+	}{
+		{"51584179", MatchedByLink},
+		{"120259174", MatchedByISRC},
+		{"51584179", MatchedBySearch},
+		{"", ""},
+		{"", ""},
+		{"", ""},
+	}
+
+	for i, match := range matches {
+		id := ""
+		if match.Track != nil {
+			id = match.Track.ID
+		}
+
+		if id != want[i].id || match.MatchedBy != want[i].matchedBy || match.Err != nil {
+			t.Errorf("Client.ResolvePlaylistEntries() entry %d = %s by %q (%v), want %s by %q",
+				i, id, match.MatchedBy, match.Err, want[i].id, want[i].matchedBy)
+		}
+
+		if match.Entry != entries[i] {
+			t.Errorf("Client.ResolvePlaylistEntries() entry %d = %+v, want %+v", i, match.Entry, entries[i])
+		}
+	}
+
+	if progress != len(entries) {
+		t.Errorf("Client.ResolvePlaylistEntries() progress = %d, want %d", progress, len(entries))
+	}
+}
@@ -0,0 +1,166 @@
+package gotidal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ResolveOptions controls how bulk lookups fan out. Requests that fail with a transient error are retried up to the
+// client's MaxRetries.
+type ResolveOptions struct {
+	RateLimit
+
+	// Progress is called after each identifier has been looked up, with the number of identifiers done and the total
+	// number of unique identifiers. It is never called concurrently.
+	Progress func(done int, total int)
+}
+
+// ResolveResults holds the outcome of a bulk lookup, keyed by normalized identifier.
+type ResolveResults[K ~string, T any] struct {
+	// Matches holds the resources found for each identifier.
+	Matches map[K][]T
+
+	// NotFound lists the identifiers without any matches, in the order they were first given.
+	NotFound []K
+
+	// Invalid lists the input that could not be parsed as an identifier, as it was given.
+	Invalid []string
+
+	// Errors holds the identifiers that could not be looked up and why.
+	Errors map[K]error
+}
+
+// ResolveISRCs looks up the tracks for many ISRCs at once. The ISRCs are normalized with ParseISRC and duplicates are
+// looked up once.
+func (c *Client) ResolveISRCs(
+	ctx context.Context, isrcs []string, options ResolveOptions,
+) (*ResolveResults[ISRC, Track], error) {
+	return resolveIdentifiers(ctx, isrcs, ParseISRC, options, c.lookupISRC)
+}
+
+// ResolveBarcodes looks up the albums for many UPC-A or EAN-13 barcodes at once. The barcodes are normalized with
+// ParseBarcode, so the UPC-A and EAN-13 forms of the same barcode are looked up once.
+func (c *Client) ResolveBarcodes(
+	ctx context.Context, barcodes []string, options ResolveOptions,
+) (*ResolveResults[Barcode, Album], error) {
+	return resolveIdentifiers(ctx, barcodes, ParseBarcode, options, c.lookupBarcode)
+}
+
+func (c *Client) lookupISRC(ctx context.Context, isrc ISRC) ([]Track, error) {
+	return c.GetTracksByISRC(ctx, isrc.String(), PaginationParams{Limit: paginationLimit})
+}
+
+func (c *Client) lookupBarcode(ctx context.Context, barcode Barcode) ([]Album, error) {
+	return c.GetAlbumByBarcodeID(ctx, barcode.String())
+}
+
+func resolveIdentifiers[K ~string, T any](
+	ctx context.Context, identifiers []string, parse func(string) (K, error), options ResolveOptions,
+	lookup func(ctx context.Context, id K) ([]T, error),
+) (*ResolveResults[K, T], error) {
+	unique, invalid := uniqueIdentifiers(identifiers, parse)
+	matches := make([][]T, len(unique))
+	errs := make([]error, len(unique))
+
+	var (
+		mu   sync.Mutex
+		done int
+	)
+
+	runLimited(ctx, len(unique), options.Concurrency, options.Interval, func(i int, err error) {
+		if err == nil {
+			matches[i], err = lookup(ctx, unique[i])
+		}
+
+		if isNotFound(err) {
+			err = nil
+		}
+
+		errs[i] = err
+
+		if options.Progress != nil {
+			mu.Lock()
+			defer mu.Unlock()
+
+			done++
+			options.Progress(done, len(unique))
+		}
+	})
+
+	results := collateResolved(unique, matches, errs)
+	results.Invalid = invalid
+
+	if err := ctx.Err(); err != nil {
+		return results, fmt.Errorf("bulk lookup stopped: %w", err)
+	}
+
+	return results, nil
+}
+
+// uniqueIdentifiers parses the identifiers and drops duplicates and blank lines, keeping the order in which they were
+// first given. The input that could not be parsed is returned as it was given.
+func uniqueIdentifiers[K ~string](identifiers []string, parse func(string) (K, error)) ([]K, []string) {
+	var (
+		unique  []K
+		invalid []string
+	)
+
+	seen := map[K]bool{}
+
+	for _, identifier := range identifiers {
+		if strings.TrimSpace(identifier) == "" {
+			continue
+		}
+
+		id, err := parse(identifier)
+		if err != nil {
+			invalid = append(invalid, identifier)
+			continue
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique, invalid
+}
+
+func collateResolved[K ~string, T any](unique []K, matches [][]T, errs []error) *ResolveResults[K, T] {
+	results := &ResolveResults[K, T]{
+		Matches: map[K][]T{},
+		Errors:  map[K]error{},
+	}
+
+	for i, id := range unique {
+		switch {
+		case errs[i] != nil:
+			results.Errors[id] = errs[i]
+		case len(matches[i]) == 0:
+			results.NotFound = append(results.NotFound, id)
+		default:
+			results.Matches[id] = matches[i]
+		}
+	}
+
+	return results
+}
+
+// isNotFound reports whether the API responded that a resource does not exist.
+func isNotFound(err error) bool {
+	var responseError *ResponseError
+
+	return errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotFound
+}
+
+// isPreconditionFailed reports whether the API rejected a conditional request because the resource has changed.
+func isPreconditionFailed(err error) bool {
+	var responseError *ResponseError
+
+	return errors.As(err, &responseError) && responseError.StatusCode == http.StatusPreconditionFailed
+}
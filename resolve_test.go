@@ -0,0 +1,142 @@
+package gotidal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_ResolveISRCs(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		switch r.URL.Query().Get("isrc") {
+		case "GBAAP1500379":
+			http.ServeFile(w, r, "testdata/tracks-by-isrc.json")
+		case "GBAYE6700149":
+			w.WriteHeader(http.StatusBadRequest)
+		case "USSM12204871":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client(), Environment: server.URL, CountryCode: countryCode}
+
+	var progress []int
+
+	results, err := client.ResolveISRCs(context.Background(), []string{
+		"GBAAP1500379", "gb-aap-15-00379", "USSM12204871", "GBAYE6700149", "GBAAP1500380", "not an isrc", "",
+	}, ResolveOptions{
+		RateLimit: RateLimit{Concurrency: 1},
+		Progress: func(done int, total int) {
+			if total != 4 {
+				t.Errorf("ResolveOptions.Progress() total = %v, want 4", total)
+			}
+
+			progress = append(progress, done)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Client.ResolveISRCs() error = %v", err)
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 4 {
+		t.Errorf("Client.ResolveISRCs() sent %d requests, want one per unique ISRC", got)
+	}
+
+	if got := len(results.Matches["GBAAP1500379"]); got != 2 {
+		t.Errorf("Client.ResolveISRCs() matched %d tracks, want 2", got)
+	}
+
+	if want := []ISRC{"USSM12204871", "GBAAP1500380"}; !reflect.DeepEqual(results.NotFound, want) {
+		t.Errorf("Client.ResolveISRCs() NotFound = %v, want %v", results.NotFound, want)
+	}
+
+	if want := []string{"not an isrc"}; !reflect.DeepEqual(results.Invalid, want) {
+		t.Errorf("Client.ResolveISRCs() Invalid = %v, want %v", results.Invalid, want)
+	}
+
+	if _, ok := results.Errors["GBAYE6700149"]; !ok || len(results.Errors) != 1 {
+		t.Errorf("Client.ResolveISRCs() Errors = %v, want an error for GBAYE6700149", results.Errors)
+	}
+
+	if want := []int{1, 2, 3, 4}; !reflect.DeepEqual(progress, want) {
+		t.Errorf("ResolveOptions.Progress() calls = %v, want %v", progress, want)
+	}
+}
+
+func TestClient_ResolveBarcodes(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		if r.URL.Query().Get("barcodeId") == "825646008339" {
+			http.ServeFile(w, r, "testdata/multiple-albums.json")
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client(), Environment: server.URL, CountryCode: countryCode}
+
+	results, err := client.ResolveBarcodes(
+		context.Background(), []string{"825646008339", "0825646008339", "4006381333931"}, ResolveOptions{},
+	)
+	if err != nil {
+		t.Fatalf("Client.ResolveBarcodes() error = %v", err)
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("Client.ResolveBarcodes() sent %d requests, want the UPC-A and EAN-13 forms deduplicated", got)
+	}
+
+	if len(results.Matches["0825646008339"]) == 0 {
+		t.Errorf("Client.ResolveBarcodes() Matches = %v, want albums for 0825646008339", results.Matches)
+	}
+
+	if want := []Barcode{"4006381333931"}; !reflect.DeepEqual(results.NotFound, want) {
+		t.Errorf("Client.ResolveBarcodes() NotFound = %v, want %v", results.NotFound, want)
+	}
+}
+
+func TestClient_ResolveISRCsCanceled(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{
+		httpClient:  &mockHTTPClient{FilePath: "testdata/tracks-by-isrc.json", StatusCode: http.StatusOK},
+		CountryCode: countryCode,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := client.ResolveISRCs(ctx, []string{"GBAAP1500379", "not an isrc"}, ResolveOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Client.ResolveISRCs() error = %v, want %v", err, context.Canceled)
+	}
+
+	if _, ok := results.Errors["GBAAP1500379"]; !ok {
+		t.Errorf("Client.ResolveISRCs() Errors = %v, want the ISRC that was not looked up", results.Errors)
+	}
+
+	if want := []string{"not an isrc"}; !reflect.DeepEqual(results.Invalid, want) {
+		t.Errorf("Client.ResolveISRCs() Invalid = %v, want %v", results.Invalid, want)
+	}
+}
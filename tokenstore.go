@@ -0,0 +1,353 @@
+package gotidal
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// tokenFilePerm restricts token files to the current user as they contain credentials.
+	tokenFilePerm = 0o600
+
+	tokenLockPollInterval = 50 * time.Millisecond
+
+	// tokenLockStaleAge is how old a lock file must be before it is treated as left behind by a process that exited
+	// while holding it.
+	tokenLockStaleAge = time.Minute
+)
+
+var (
+	ErrTokenNotFound = errors.New("no token has been saved")
+	ErrTokenDecrypt  = errors.New("failed to decrypt the token file")
+)
+
+// TokenStore persists tokens between sessions so clients don't need to re-authenticate on every start.
+//
+// Load returns ErrTokenNotFound when no token has been saved. Implementations must be safe for concurrent use.
+type TokenStore interface {
+	Load(ctx context.Context) (*UserToken, error)
+	Save(ctx context.Context, token *UserToken) error
+	Delete(ctx context.Context) error
+}
+
+// TokenLocker is implemented by token stores that can be shared by several clients. Clients hold the lock while they
+// reload, refresh and save the token, so only one of them refreshes it at a time and a rotated refresh token is never
+// used twice.
+type TokenLocker interface {
+	// Lock blocks until the lock is held or the context is done, and returns a function that releases it.
+	Lock(ctx context.Context) (func(), error)
+}
+
+// MemoryTokenStore keeps a token in memory. It is useful for tests and for sharing a token between clients in the
+// same process.
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *UserToken
+
+	// refresh is held by a client refreshing the token.
+	refresh sync.Mutex
+}
+
+// NewMemoryTokenStore returns an empty in-memory token store.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+// Load implements TokenStore.
+func (s *MemoryTokenStore) Load(_ context.Context) (*UserToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil {
+		return nil, ErrTokenNotFound
+	}
+
+	token := *s.token
+
+	return &token, nil
+}
+
+// Save implements TokenStore.
+func (s *MemoryTokenStore) Save(_ context.Context, token *UserToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := *token
+	s.token = &saved
+
+	return nil
+}
+
+// Delete implements TokenStore.
+func (s *MemoryTokenStore) Delete(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = nil
+
+	return nil
+}
+
+// Lock implements TokenLocker.
+func (s *MemoryTokenStore) Lock(_ context.Context) (func(), error) {
+	s.refresh.Lock()
+
+	return s.refresh.Unlock, nil
+}
+
+// FileTokenStore keeps a token in a file that is only readable by the current user.
+//
+// Tokens are written to a temporary file and renamed into place so processes sharing the file never read a partial
+// write. Clients refresh the token while holding a lock file next to it, and reload the store first, so a token
+// refreshed by one process is picked up by the others rather than refreshed again and overwritten.
+type FileTokenStore struct {
+	mu   sync.Mutex
+	path string
+	aead cipher.AEAD
+}
+
+// NewFileTokenStore returns a token store that saves the token as JSON at path.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+// NewEncryptedFileTokenStore returns a token store that encrypts the token at rest with AES-GCM. The key must be 16,
+// 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+func NewEncryptedFileTokenStore(path string, key []byte) (*FileTokenStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the token cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the token cipher: %w", err)
+	}
+
+	return &FileTokenStore{path: path, aead: aead}, nil
+}
+
+// Load implements TokenStore.
+func (s *FileTokenStore) Load(_ context.Context) (*UserToken, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrTokenNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the token file: %w", err)
+	}
+
+	data, err = s.decrypt(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var token UserToken
+
+	err = json.Unmarshal(data, &token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the token file: %w", err)
+	}
+
+	return &token, nil
+}
+
+// Save implements TokenStore.
+func (s *FileTokenStore) Save(_ context.Context, token *UserToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the token: %w", err)
+	}
+
+	data, err = s.encrypt(data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return writeFileAtomic(s.path, data, tokenFilePerm)
+}
+
+// Delete implements TokenStore.
+func (s *FileTokenStore) Delete(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(s.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete the token file: %w", err)
+	}
+
+	return nil
+}
+
+// Lock implements TokenLocker with a lock file created next to the token file. A lock file older than a minute is
+// treated as left behind by a process that exited without removing it.
+func (s *FileTokenStore) Lock(ctx context.Context) (func(), error) {
+	lockPath := concat(s.path, ".lock")
+
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, tokenFilePerm)
+		if err == nil {
+			_ = file.Close()
+
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("failed to create the token lock file: %w", err)
+		}
+
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > tokenLockStaleAge {
+			_ = os.Remove(lockPath)
+
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to lock the token file: %w", ctx.Err())
+		case <-time.After(tokenLockPollInterval):
+		}
+	}
+}
+
+func (s *FileTokenStore) encrypt(data []byte) ([]byte, error) {
+	if s.aead == nil {
+		return data, nil
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate a nonce: %w", err)
+	}
+
+	return s.aead.Seal(nonce, nonce, data, nil), nil
+}
+
+func (s *FileTokenStore) decrypt(data []byte) ([]byte, error) {
+	if s.aead == nil {
+		return data, nil
+	}
+
+	if len(data) < s.aead.NonceSize() {
+		return nil, ErrTokenDecrypt
+	}
+
+	nonce, ciphertext := data[:s.aead.NonceSize()], data[s.aead.NonceSize():]
+
+	plaintext, err := s.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTokenDecrypt, err)
+	}
+
+	return plaintext, nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and renames it over path, so readers see
+// either the old or the new contents.
+func writeFileAtomic(path string, data []byte, perm fs.FileMode) error {
+	file, err := os.CreateTemp(filepath.Dir(path), concat(filepath.Base(path), ".*.tmp"))
+	if err != nil {
+		return fmt.Errorf("failed to create the temporary file: %w", err)
+	}
+
+	tempPath := file.Name()
+
+	defer os.Remove(tempPath) // nolint:errcheck // The file no longer exists once it has been renamed.
+
+	err = file.Chmod(perm)
+	if err == nil {
+		_, err = file.Write(data)
+	}
+
+	if err == nil {
+		err = file.Sync()
+	}
+
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write the temporary file: %w", err)
+	}
+
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// NewClientWithStore returns an API client like NewClient, but reuses the access token held in the store while it is
+// valid. New tokens are saved to the store and requested again automatically when they expire.
+func NewClientWithStore(
+	ctx context.Context, clientID string, clientSecret string, countryCode string, store TokenStore,
+) (*Client, error) {
+	code, err := ParseCountryCode(countryCode)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{}
+
+	tokenSource := &refreshingTokenSource{
+		store: store,
+		refresh: func(ctx context.Context, _ *UserToken) (*UserToken, error) {
+			return getClientToken(ctx, httpClient, clientID, clientSecret)
+		},
+	}
+
+	_, err = tokenSource.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		httpClient:    httpClient,
+		ContentType:   contentType,
+		Environment:   environment,
+		EnvironmentV2: environmentV2,
+		CountryCode:   string(code),
+		MaxRetries:    defaultMaxRetries,
+		cache:         newResponseCache(),
+		tokenSource:   tokenSource,
+	}, nil
+}
+
+// NewUserClientWithStore returns an API client that acts on behalf of the user whose token is held in the store.
+// Refreshed tokens are saved back to the store, and the token is deleted if TIDAL revokes it.
+func NewUserClientWithStore(
+	ctx context.Context, config OAuthConfig, store TokenStore, countryCode string,
+) (*Client, error) {
+	code, err := ParseCountryCode(countryCode)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the user token: %w", err)
+	}
+
+	return newUserClient(config, token, store, string(code)), nil
+}
@@ -0,0 +1,411 @@
+package gotidal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryTokenStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryTokenStore()
+
+	_, err := store.Load(ctx)
+	if !errors.Is(err, ErrTokenNotFound) {
+		t.Fatalf("MemoryTokenStore.Load() error = %v, want %v", err, ErrTokenNotFound)
+	}
+
+	token := &UserToken{AccessToken: "access", RefreshToken: "refresh"}
+
+	err = store.Save(ctx, token)
+	if err != nil {
+		t.Fatalf("MemoryTokenStore.Save() error = %v", err)
+	}
+
+	token.AccessToken = "changed"
+
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("MemoryTokenStore.Load() error = %v", err)
+	}
+
+	if loaded.AccessToken != "access" {
+		t.Errorf("MemoryTokenStore.Load() AccessToken = %v, want %v", loaded.AccessToken, "access")
+	}
+
+	err = store.Delete(ctx)
+	if err != nil {
+		t.Fatalf("MemoryTokenStore.Delete() error = %v", err)
+	}
+
+	_, err = store.Load(ctx)
+	if !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("MemoryTokenStore.Load() error = %v, want %v", err, ErrTokenNotFound)
+	}
+}
+
+func TestFileTokenStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := NewFileTokenStore(path)
+
+	_, err := store.Load(ctx)
+	if !errors.Is(err, ErrTokenNotFound) {
+		t.Fatalf("FileTokenStore.Load() error = %v, want %v", err, ErrTokenNotFound)
+	}
+
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	err = store.Save(ctx, &UserToken{AccessToken: "access", RefreshToken: "refresh", Expiry: expiry})
+	if err != nil {
+		t.Fatalf("FileTokenStore.Save() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("os.Stat() error = %v", err)
+	}
+
+	if runtime.GOOS != "windows" && info.Mode().Perm() != tokenFilePerm {
+		t.Errorf("FileTokenStore.Save() permissions = %v, want %v", info.Mode().Perm(), os.FileMode(tokenFilePerm))
+	}
+
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("FileTokenStore.Load() error = %v", err)
+	}
+
+	if loaded.RefreshToken != "refresh" || !loaded.Expiry.Equal(expiry) {
+		t.Errorf("FileTokenStore.Load() = %+v", loaded)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("os.ReadDir() error = %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("FileTokenStore.Save() left %d files behind, want 1", len(entries))
+	}
+
+	err = store.Delete(ctx)
+	if err != nil {
+		t.Fatalf("FileTokenStore.Delete() error = %v", err)
+	}
+
+	err = store.Delete(ctx)
+	if err != nil {
+		t.Errorf("FileTokenStore.Delete() on a missing file error = %v", err)
+	}
+}
+
+func TestEncryptedFileTokenStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "token.bin")
+	key := bytes.Repeat([]byte("k"), 32)
+
+	_, err := NewEncryptedFileTokenStore(path, []byte("short"))
+	if err == nil {
+		t.Error("NewEncryptedFileTokenStore() expected an error for an invalid key")
+	}
+
+	store, err := NewEncryptedFileTokenStore(path, key)
+	if err != nil {
+		t.Fatalf("NewEncryptedFileTokenStore() error = %v", err)
+	}
+
+	err = store.Save(ctx, &UserToken{AccessToken: "secret-access-token"})
+	if err != nil {
+		t.Fatalf("FileTokenStore.Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+
+	if bytes.Contains(data, []byte("secret-access-token")) {
+		t.Error("FileTokenStore.Save() wrote the token in plain text")
+	}
+
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("FileTokenStore.Load() error = %v", err)
+	}
+
+	if loaded.AccessToken != "secret-access-token" {
+		t.Errorf("FileTokenStore.Load() AccessToken = %v, want %v", loaded.AccessToken, "secret-access-token")
+	}
+
+	wrongKey, err := NewEncryptedFileTokenStore(path, bytes.Repeat([]byte("x"), 32))
+	if err != nil {
+		t.Fatalf("NewEncryptedFileTokenStore() error = %v", err)
+	}
+
+	_, err = wrongKey.Load(ctx)
+	if !errors.Is(err, ErrTokenDecrypt) {
+		t.Errorf("FileTokenStore.Load() error = %v, want %v", err, ErrTokenDecrypt)
+	}
+}
+
+func TestRefreshingTokenSource_store(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	expired := &UserToken{AccessToken: "expired", RefreshToken: "old", Expiry: time.Now().Add(-time.Hour)}
+
+	t.Run("Uses a token refreshed by another process", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryTokenStore()
+		source := &refreshingTokenSource{
+			token: expired,
+			store: store,
+			refresh: func(_ context.Context, _ *UserToken) (*UserToken, error) {
+				t.Error("refresh should not be called when the store holds a valid token")
+				return nil, ErrMissingRefreshToken
+			},
+		}
+
+		_ = store.Save(ctx, &UserToken{AccessToken: "other", Expiry: time.Now().Add(time.Hour)})
+
+		token, err := source.Token(ctx)
+		if err != nil {
+			t.Fatalf("refreshingTokenSource.Token() error = %v", err)
+		}
+
+		if token.AccessToken != "other" {
+			t.Errorf("refreshingTokenSource.Token() AccessToken = %v, want %v", token.AccessToken, "other")
+		}
+	})
+
+	t.Run("Saves refreshed tokens", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryTokenStore()
+		source := &refreshingTokenSource{
+			token: expired,
+			store: store,
+			refresh: func(_ context.Context, _ *UserToken) (*UserToken, error) {
+				return &UserToken{AccessToken: "refreshed", Expiry: time.Now().Add(time.Hour)}, nil
+			},
+		}
+
+		_, err := source.Token(ctx)
+		if err != nil {
+			t.Fatalf("refreshingTokenSource.Token() error = %v", err)
+		}
+
+		saved, err := store.Load(ctx)
+		if err != nil || saved.AccessToken != "refreshed" {
+			t.Errorf("refreshingTokenSource.Token() saved = %+v, %v", saved, err)
+		}
+	})
+
+	t.Run("Deletes revoked tokens", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryTokenStore()
+		source := &refreshingTokenSource{
+			token: expired,
+			store: store,
+			refresh: func(_ context.Context, _ *UserToken) (*UserToken, error) {
+				return nil, &ResponseError{StatusCode: http.StatusBadRequest, Body: []byte(`{"error": "invalid_grant"}`)}
+			},
+		}
+
+		_ = store.Save(ctx, expired)
+
+		_, err := source.Token(ctx)
+		if !errors.Is(err, ErrUnexpectedResponseCode) {
+			t.Fatalf("refreshingTokenSource.Token() error = %v, want %v", err, ErrUnexpectedResponseCode)
+		}
+
+		_, err = store.Load(ctx)
+		if !errors.Is(err, ErrTokenNotFound) {
+			t.Errorf("refreshingTokenSource.Token() did not delete the revoked token: %v", err)
+		}
+	})
+
+	t.Run("Keeps a token saved by another client after a failed refresh", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryTokenStore()
+		other := &UserToken{AccessToken: "other", RefreshToken: "rotated", Expiry: time.Now().Add(-time.Hour)}
+		source := &refreshingTokenSource{
+			token: expired,
+			store: store,
+			refresh: func(ctx context.Context, _ *UserToken) (*UserToken, error) {
+				_ = store.Save(ctx, other)
+
+				return nil, &ResponseError{StatusCode: http.StatusBadRequest, Body: []byte(`{"error": "invalid_grant"}`)}
+			},
+		}
+
+		_, err := source.Token(ctx)
+		if !errors.Is(err, ErrUnexpectedResponseCode) {
+			t.Fatalf("refreshingTokenSource.Token() error = %v, want %v", err, ErrUnexpectedResponseCode)
+		}
+
+		saved, err := store.Load(ctx)
+		if err != nil || saved.RefreshToken != "rotated" {
+			t.Errorf("refreshingTokenSource.Token() deleted the token saved by another client: %+v, %v", saved, err)
+		}
+	})
+
+	t.Run("Reports a failed client credentials request for an expired stored token", func(t *testing.T) {
+		t.Parallel()
+
+		// NewClientWithStore starts without a token and requests client credentials, which have no refresh token.
+		store := NewMemoryTokenStore()
+		_ = store.Save(ctx, &UserToken{AccessToken: "client", Expiry: time.Now().Add(-time.Hour)})
+
+		source := &refreshingTokenSource{
+			store: store,
+			refresh: func(_ context.Context, _ *UserToken) (*UserToken, error) {
+				return nil, &ResponseError{StatusCode: http.StatusServiceUnavailable}
+			},
+		}
+
+		_, err := source.Token(ctx)
+		if !errors.Is(err, ErrUnexpectedResponseCode) {
+			t.Fatalf("refreshingTokenSource.Token() error = %v, want %v", err, ErrUnexpectedResponseCode)
+		}
+
+		saved, err := store.Load(ctx)
+		if err != nil || saved.AccessToken != "client" {
+			t.Errorf("refreshingTokenSource.Token() changed the stored token: %+v, %v", saved, err)
+		}
+	})
+}
+
+func TestRefreshingTokenSource_sharedFile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "token.json")
+	expired := &UserToken{AccessToken: "expired", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Hour)}
+
+	err := NewFileTokenStore(path).Save(ctx, expired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The token endpoint rotates the refresh token on every refresh and rejects the previous one.
+	var (
+		mu        sync.Mutex
+		current   = "refresh-0"
+		refreshes int
+	)
+
+	refresh := func(_ context.Context, token *UserToken) (*UserToken, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		refreshes++
+
+		if token.RefreshToken != current {
+			return nil, &ResponseError{StatusCode: http.StatusBadRequest, Body: []byte(`{"error": "invalid_grant"}`)}
+		}
+
+		current = fmt.Sprintf("refresh-%d", refreshes)
+
+		return &UserToken{AccessToken: "refreshed", RefreshToken: current, Expiry: time.Now().Add(time.Hour)}, nil
+	}
+
+	// Each source has its own store for the same file, as separate processes would.
+	var wg sync.WaitGroup
+
+	errs := make([]error, 4)
+
+	for i := range errs {
+		source := &refreshingTokenSource{token: expired, store: NewFileTokenStore(path), refresh: refresh}
+
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			_, errs[i] = source.Token(ctx)
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("refreshingTokenSource.Token() %d error = %v", i, err)
+		}
+	}
+
+	if refreshes != 1 {
+		t.Errorf("refreshingTokenSource.Token() tried to refresh %d times, want 1", refreshes)
+	}
+
+	saved, err := NewFileTokenStore(path).Load(ctx)
+	if err != nil || saved.RefreshToken != "refresh-1" {
+		t.Errorf("refreshingTokenSource.Token() saved = %+v, %v", saved, err)
+	}
+}
+
+func TestFileTokenStore_Lock(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := NewFileTokenStore(path)
+
+	unlock, err := store.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("FileTokenStore.Lock() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = NewFileTokenStore(path).Lock(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("FileTokenStore.Lock() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	unlock()
+
+	unlock, err = NewFileTokenStore(path).Lock(context.Background())
+	if err != nil {
+		t.Fatalf("FileTokenStore.Lock() error = %v after unlocking", err)
+	}
+
+	unlock()
+
+	// A lock file left behind by a process that exited is taken over once it is stale.
+	stale := time.Now().Add(-2 * tokenLockStaleAge)
+
+	err = os.WriteFile(path+".lock", nil, tokenFilePerm)
+	if err == nil {
+		err = os.Chtimes(path+".lock", stale, stale)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unlock, err = store.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("FileTokenStore.Lock() error = %v with a stale lock file", err)
+	}
+
+	unlock()
+}
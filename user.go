@@ -0,0 +1,140 @@
+package gotidal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Audio qualities in ascending order of fidelity.
+const (
+	AudioQualityLow           = "LOW"
+	AudioQualityHigh          = "HIGH"
+	AudioQualityLossless      = "LOSSLESS"
+	AudioQualityHiRes         = "HI_RES"
+	AudioQualityHiResLossless = "HI_RES_LOSSLESS"
+)
+
+// Media metadata tags that indicate the qualities a track is available in.
+const (
+	MediaTagLossless      = "LOSSLESS"
+	MediaTagMQA           = "MQA"
+	MediaTagHiResLossless = "HIRES_LOSSLESS"
+	MediaTagDolbyAtmos    = "DOLBY_ATMOS"
+)
+
+// User represents the user a client is acting on behalf of.
+type User struct {
+	userResource `json:"resource"`
+}
+
+type userResource struct {
+	ID           string       `json:"id"`
+	Username     string       `json:"username"`
+	CountryCode  string       `json:"countryCode"`
+	Subscription Subscription `json:"subscription"`
+}
+
+// Subscription represents the subscription tier of a user and the qualities it entitles them to.
+type Subscription struct {
+	Type string `json:"type"`
+
+	// HighestSoundQuality is the best audio quality the user can stream.
+	// Example: HIGH, LOSSLESS, HI_RES, HI_RES_LOSSLESS
+	HighestSoundQuality string `json:"highestSoundQuality"`
+
+	// PremiumAccess reports whether the subscription includes immersive formats such as Dolby Atmos.
+	PremiumAccess bool `json:"premiumAccess"`
+}
+
+// GetMe returns the user the client is acting on behalf of. It requires a client created with a user token.
+func (c *Client) GetMe(ctx context.Context) (*User, error) {
+	response, err := c.request(ctx, http.MethodGet, "/users/me", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the users endpoint: %w", err)
+	}
+
+	var result User
+
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the users response body: %w", err)
+	}
+
+	return &result, nil
+}
+
+// audioQualityRank orders the audio qualities so they can be compared. Unknown qualities rank below LOW.
+func audioQualityRank(quality string) int {
+	switch quality {
+	case AudioQualityLow:
+		return 1
+	case AudioQualityHigh:
+		return 2 // nolint:gomnd // Ranks are only meaningful relative to each other.
+	case AudioQualityLossless:
+		return 3 // nolint:gomnd // Ranks are only meaningful relative to each other.
+	case AudioQualityHiRes:
+		return 4 // nolint:gomnd // Ranks are only meaningful relative to each other.
+	case AudioQualityHiResLossless:
+		return 5 // nolint:gomnd // Ranks are only meaningful relative to each other.
+	default:
+		return 0
+	}
+}
+
+// AvailableQualities returns the audio qualities a track is available in, based on its media metadata tags. Every
+// track is available in the lossy LOW and HIGH qualities.
+func (m MediaMetaData) AvailableQualities() []string {
+	qualities := []string{AudioQualityLow, AudioQualityHigh}
+
+	for _, tag := range m.Tags {
+		switch tag {
+		case MediaTagLossless:
+			qualities = append(qualities, AudioQualityLossless)
+		case MediaTagMQA:
+			qualities = append(qualities, AudioQualityHiRes)
+		case MediaTagHiResLossless:
+			qualities = append(qualities, AudioQualityHiResLossless)
+		}
+	}
+
+	return qualities
+}
+
+// StreamableQuality returns the best audio quality the subscription can stream for media with the given metadata,
+// so interfaces only show quality badges the user can actually use.
+func (s Subscription) StreamableQuality(metadata MediaMetaData) string {
+	limit := audioQualityRank(s.HighestSoundQuality)
+	best := ""
+
+	for _, quality := range metadata.AvailableQualities() {
+		rank := audioQualityRank(quality)
+		if rank <= limit && rank > audioQualityRank(best) {
+			best = quality
+		}
+	}
+
+	return best
+}
+
+// CanPlayDolbyAtmos reports whether the subscription can stream the Dolby Atmos version of media with the given
+// metadata.
+func (s Subscription) CanPlayDolbyAtmos(metadata MediaMetaData) bool {
+	if !s.PremiumAccess {
+		return false
+	}
+
+	for _, tag := range metadata.Tags {
+		if tag == MediaTagDolbyAtmos {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StreamableQuality returns the best audio quality the user can stream for a track.
+func (u *User) StreamableQuality(track *Track) string {
+	return u.Subscription.StreamableQuality(track.MediaMetaData)
+}
@@ -0,0 +1,154 @@
+package gotidal
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetMe(t *testing.T) {
+	t.Parallel()
+
+	type expected struct {
+		ID                  string
+		CountryCode         string
+		SubscriptionType    string
+		HighestSoundQuality string
+	}
+
+	tests := []struct {
+		name       string
+		httpClient HTTPClient
+		expected   expected
+		wantErr    bool
+	}{
+		{
+			"Token Error",
+			&mockHTTPClient{FilePath: "testdata/401-token-error.json", StatusCode: http.StatusUnauthorized},
+			expected{},
+			true,
+		},
+		{
+			"User parses correctly",
+			&mockHTTPClient{FilePath: "testdata/user-me.json", StatusCode: http.StatusOK},
+			expected{
+				ID:                  "123456",
+				CountryCode:         "AU",
+				SubscriptionType:    "HIFI",
+				HighestSoundQuality: AudioQualityLossless,
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{httpClient: tt.httpClient, CountryCode: countryCode}
+
+			user, err := client.GetMe(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetMe() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if user.ID != tt.expected.ID {
+				t.Errorf("Client.GetMe() ID = %v, want %v", user.ID, tt.expected.ID)
+			}
+
+			if user.CountryCode != tt.expected.CountryCode {
+				t.Errorf("Client.GetMe() CountryCode = %v, want %v", user.CountryCode, tt.expected.CountryCode)
+			}
+
+			if user.Subscription.Type != tt.expected.SubscriptionType {
+				t.Errorf("Client.GetMe() Subscription = %v, want %v", user.Subscription.Type, tt.expected.SubscriptionType)
+			}
+
+			if user.Subscription.HighestSoundQuality != tt.expected.HighestSoundQuality {
+				t.Errorf("Client.GetMe() HighestSoundQuality = %v, want %v",
+					user.Subscription.HighestSoundQuality, tt.expected.HighestSoundQuality)
+			}
+		})
+	}
+}
+
+func TestSubscription_StreamableQuality(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		subscription Subscription
+		tags         []string
+		want         string
+	}{
+		{
+			"Lossy track on a lossless subscription",
+			Subscription{HighestSoundQuality: AudioQualityLossless},
+			nil,
+			AudioQualityHigh,
+		},
+		{
+			"Hi-res track limited to lossless",
+			Subscription{HighestSoundQuality: AudioQualityLossless},
+			[]string{MediaTagLossless, MediaTagHiResLossless},
+			AudioQualityLossless,
+		},
+		{
+			"Hi-res track on a hi-res subscription",
+			Subscription{HighestSoundQuality: AudioQualityHiResLossless},
+			[]string{MediaTagLossless, MediaTagMQA, MediaTagHiResLossless},
+			AudioQualityHiResLossless,
+		},
+		{
+			"MQA track on a hi-res subscription",
+			Subscription{HighestSoundQuality: AudioQualityHiResLossless},
+			[]string{MediaTagLossless, MediaTagMQA},
+			AudioQualityHiRes,
+		},
+		{
+			"Free subscription",
+			Subscription{HighestSoundQuality: AudioQualityLow},
+			[]string{MediaTagLossless},
+			AudioQualityLow,
+		},
+		{
+			"Unknown subscription",
+			Subscription{},
+			[]string{MediaTagLossless},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.subscription.StreamableQuality(MediaMetaData{Tags: tt.tags}); got != tt.want {
+				t.Errorf("Subscription.StreamableQuality() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscription_CanPlayDolbyAtmos(t *testing.T) {
+	t.Parallel()
+
+	metadata := MediaMetaData{Tags: []string{MediaTagLossless, MediaTagDolbyAtmos}}
+
+	if (Subscription{PremiumAccess: false}).CanPlayDolbyAtmos(metadata) {
+		t.Error("Subscription.CanPlayDolbyAtmos() = true without premium access")
+	}
+
+	if !(Subscription{PremiumAccess: true}).CanPlayDolbyAtmos(metadata) {
+		t.Error("Subscription.CanPlayDolbyAtmos() = false with premium access")
+	}
+
+	if (Subscription{PremiumAccess: true}).CanPlayDolbyAtmos(MediaMetaData{}) {
+		t.Error("Subscription.CanPlayDolbyAtmos() = true for a track without Dolby Atmos")
+	}
+}
@@ -0,0 +1,263 @@
+package gotidal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	resourceTypeAlbums  = "albums"
+	resourceTypeArtists = "artists"
+)
+
+// AlbumV2 represents an album returned by the TIDAL v2 API, with its related artists resolved from the included
+// resources.
+type AlbumV2 struct {
+	ID string
+	AlbumAttributes
+
+	// Artists only contain attributes when the artists relationship was requested in V2Params.Include.
+	Artists []ArtistV2
+}
+
+// AlbumAttributes represents the attributes of a v2 album resource.
+type AlbumAttributes struct {
+	Title           string   `json:"title"`
+	BarcodeID       string   `json:"barcodeId"`
+	NumberOfVolumes int      `json:"numberOfVolumes"`
+	NumberOfItems   int      `json:"numberOfItems"`
+	Duration        string   `json:"duration"`
+	Explicit        bool     `json:"explicit"`
+	ReleaseDate     string   `json:"releaseDate"`
+	Popularity      float64  `json:"popularity"`
+	MediaTags       []string `json:"mediaTags"`
+	Type            string   `json:"type"`
+}
+
+// ArtistV2 represents an artist returned by the TIDAL v2 API.
+type ArtistV2 struct {
+	ID string
+	ArtistAttributes
+}
+
+// ArtistAttributes represents the attributes of a v2 artist resource.
+type ArtistAttributes struct {
+	Name       string  `json:"name"`
+	Popularity float64 `json:"popularity"`
+}
+
+// TrackV2 represents a track returned by the TIDAL v2 API, with its related albums and artists resolved from the
+// included resources.
+type TrackV2 struct {
+	ID string
+	TrackAttributes
+
+	// Albums and Artists only contain attributes when the relationships were requested in V2Params.Include.
+	Albums  []AlbumV2
+	Artists []ArtistV2
+}
+
+// TrackAttributes represents the attributes of a v2 track resource.
+type TrackAttributes struct {
+	Title      string   `json:"title"`
+	Version    string   `json:"version"`
+	ISRC       string   `json:"isrc"`
+	Duration   string   `json:"duration"`
+	Explicit   bool     `json:"explicit"`
+	Popularity float64  `json:"popularity"`
+	MediaTags  []string `json:"mediaTags"`
+}
+
+func newArtistV2(resource ResourceObject) (ArtistV2, error) {
+	artist := ArtistV2{ID: resource.ID}
+
+	err := resource.DecodeAttributes(&artist.ArtistAttributes)
+
+	return artist, err
+}
+
+func newAlbumV2(resource ResourceObject, index includedIndex) (AlbumV2, error) {
+	album := AlbumV2{ID: resource.ID}
+
+	err := resource.DecodeAttributes(&album.AlbumAttributes)
+	if err != nil {
+		return album, err
+	}
+
+	for _, related := range index.related(resource, resourceTypeArtists) {
+		artist, err := newArtistV2(related)
+		if err != nil {
+			return album, err
+		}
+
+		album.Artists = append(album.Artists, artist)
+	}
+
+	return album, nil
+}
+
+func newTrackV2(resource ResourceObject, index includedIndex) (TrackV2, error) {
+	track := TrackV2{ID: resource.ID}
+
+	err := resource.DecodeAttributes(&track.TrackAttributes)
+	if err != nil {
+		return track, err
+	}
+
+	for _, related := range index.related(resource, resourceTypeAlbums) {
+		album, err := newAlbumV2(related, index)
+		if err != nil {
+			return track, err
+		}
+
+		track.Albums = append(track.Albums, album)
+	}
+
+	for _, related := range index.related(resource, resourceTypeArtists) {
+		artist, err := newArtistV2(related)
+		if err != nil {
+			return track, err
+		}
+
+		track.Artists = append(track.Artists, artist)
+	}
+
+	return track, nil
+}
+
+// GetAlbumV2 returns an album that matches an ID using the TIDAL v2 API.
+func (c *Client) GetAlbumV2(ctx context.Context, id string, params V2Params) (*AlbumV2, error) {
+	if id == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	doc, err := c.requestV2(ctx, http.MethodGet, concat("/albums/", id), c.v2Values(ctx, params))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the v2 albums endpoint: %w", err)
+	}
+
+	if len(doc.Data.Resources) == 0 {
+		return nil, fmt.Errorf("%w: album %s", ErrNotFound, id)
+	}
+
+	album, err := newAlbumV2(doc.Data.Resources[0], newIncludedIndex(doc))
+	if err != nil {
+		return nil, err
+	}
+
+	return &album, nil
+}
+
+// GetMultipleAlbumsV2 returns a list of albums filtered by their IDs using the TIDAL v2 API.
+func (c *Client) GetMultipleAlbumsV2(ctx context.Context, ids []string, params V2Params) ([]AlbumV2, error) {
+	query := c.v2Values(ctx, params)
+	query.Set("filter[id]", strings.Join(ids, ","))
+
+	var albums []AlbumV2
+
+	err := c.requestV2Pages(ctx, "/albums", query, func(doc *Document) error {
+		index := newIncludedIndex(doc)
+
+		for _, resource := range doc.Data.Resources {
+			album, err := newAlbumV2(resource, index)
+			if err != nil {
+				return err
+			}
+
+			albums = append(albums, album)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the v2 multiple albums endpoint: %w", err)
+	}
+
+	return albums, nil
+}
+
+// GetTrackV2 returns a track that matches an ID using the TIDAL v2 API.
+func (c *Client) GetTrackV2(ctx context.Context, id string, params V2Params) (*TrackV2, error) {
+	if id == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	doc, err := c.requestV2(ctx, http.MethodGet, concat("/tracks/", id), c.v2Values(ctx, params))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the v2 tracks endpoint: %w", err)
+	}
+
+	if len(doc.Data.Resources) == 0 {
+		return nil, fmt.Errorf("%w: track %s", ErrNotFound, id)
+	}
+
+	track, err := newTrackV2(doc.Data.Resources[0], newIncludedIndex(doc))
+	if err != nil {
+		return nil, err
+	}
+
+	return &track, nil
+}
+
+// GetArtistV2 returns an artist that matches an ID using the TIDAL v2 API.
+func (c *Client) GetArtistV2(ctx context.Context, id string, params V2Params) (*ArtistV2, error) {
+	if id == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	doc, err := c.requestV2(ctx, http.MethodGet, concat("/artists/", id), c.v2Values(ctx, params))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the v2 artists endpoint: %w", err)
+	}
+
+	if len(doc.Data.Resources) == 0 {
+		return nil, fmt.Errorf("%w: artist %s", ErrNotFound, id)
+	}
+
+	artist, err := newArtistV2(doc.Data.Resources[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &artist, nil
+}
+
+// GetArtistAlbumsV2 returns every album for an artist using the TIDAL v2 API.
+//
+// The albums relationship is paginated with cursors so this follows the next links until all the albums are returned.
+// The albums are always included so their attributes are populated; V2Params.Include can add further relationships
+// such as the album artists.
+func (c *Client) GetArtistAlbumsV2(ctx context.Context, id string, params V2Params) ([]AlbumV2, error) {
+	if id == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	params.Include = append([]string{resourceTypeAlbums}, params.Include...)
+
+	var albums []AlbumV2
+
+	err := c.requestV2Pages(
+		ctx, concat("/artists/", id, "/relationships/albums"), c.v2Values(ctx, params),
+		func(doc *Document) error {
+			index := newIncludedIndex(doc)
+
+			for _, identifier := range doc.Data.Resources {
+				album, err := newAlbumV2(index.resource(identifier.ResourceIdentifier), index)
+				if err != nil {
+					return err
+				}
+
+				albums = append(albums, album)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the v2 artist albums endpoint: %w", err)
+	}
+
+	return albums, nil
+}
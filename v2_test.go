@@ -0,0 +1,182 @@
+package gotidal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestGetAlbumV2(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		httpClient HTTPClient
+		id         string
+	}
+
+	type expected struct {
+		Title       string
+		BarcodeID   string
+		MediaTags   []string
+		ArtistNames []string
+	}
+
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		wantErr  bool
+	}{
+		{
+			"Missing ID",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/v2-album.json", StatusCode: http.StatusOK},
+				id:         "",
+			},
+			expected{},
+			true,
+		},
+		{
+			"Token Error",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/401-token-error.json", StatusCode: http.StatusUnauthorized},
+				id:         "51584178",
+			},
+			expected{},
+			true,
+		},
+		{
+			"Album with included artists parses correctly",
+			args{
+				httpClient: &mockHTTPClient{FilePath: "testdata/v2-album.json", StatusCode: http.StatusOK},
+				id:         "51584178",
+			},
+			expected{
+				Title:       "Power Corruption and Lies",
+				BarcodeID:   "825646008339",
+				MediaTags:   []string{"LOSSLESS"},
+				ArtistNames: []string{"New Order"},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{httpClient: tt.args.httpClient, CountryCode: countryCode}
+
+			album, err := client.GetAlbumV2(context.Background(), tt.args.id, V2Params{Include: []string{"artists"}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetAlbumV2() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if album.Title != tt.expected.Title {
+				t.Errorf("Client.GetAlbumV2() Title = %v, want %v", album.Title, tt.expected.Title)
+			}
+
+			if album.BarcodeID != tt.expected.BarcodeID {
+				t.Errorf("Client.GetAlbumV2() BarcodeID = %v, want %v", album.BarcodeID, tt.expected.BarcodeID)
+			}
+
+			if !reflect.DeepEqual(album.MediaTags, tt.expected.MediaTags) {
+				t.Errorf("Client.GetAlbumV2() MediaTags = %v, want %v", album.MediaTags, tt.expected.MediaTags)
+			}
+
+			var artistNames []string
+			for _, artist := range album.Artists {
+				artistNames = append(artistNames, artist.Name)
+			}
+
+			if !reflect.DeepEqual(artistNames, tt.expected.ArtistNames) {
+				t.Errorf("Client.GetAlbumV2() Artists = %v, want %v", artistNames, tt.expected.ArtistNames)
+			}
+		})
+	}
+}
+
+func TestGetTrackV2(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{
+		httpClient:  &mockHTTPClient{FilePath: "testdata/v2-track.json", StatusCode: http.StatusOK},
+		CountryCode: countryCode,
+	}
+
+	track, err := client.GetTrackV2(context.Background(), "51584179", V2Params{Include: []string{"albums", "artists"}})
+	if err != nil {
+		t.Fatalf("Client.GetTrackV2() error = %v", err)
+	}
+
+	if track.ISRC != "GBAAP1500166" {
+		t.Errorf("Client.GetTrackV2() ISRC = %v, want %v", track.ISRC, "GBAAP1500166")
+	}
+
+	if len(track.Albums) != 1 || track.Albums[0].Title != "Power Corruption and Lies" {
+		t.Fatalf("Client.GetTrackV2() Albums = %v", track.Albums)
+	}
+
+	if len(track.Albums[0].Artists) != 1 || track.Albums[0].Artists[0].Name != "New Order" {
+		t.Errorf("Client.GetTrackV2() Album Artists = %v", track.Albums[0].Artists)
+	}
+
+	if len(track.Artists) != 1 || track.Artists[0].Name != "New Order" {
+		t.Errorf("Client.GetTrackV2() Artists = %v", track.Artists)
+	}
+}
+
+func TestGetArtistAlbumsV2(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filePath := "testdata/v2-artist-albums-page-1.json"
+		if r.URL.Query().Get("page[cursor]") == "page2" {
+			filePath = "testdata/v2-artist-albums-page-2.json"
+		}
+
+		if r.URL.Query().Get("include") != "albums" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client(), EnvironmentV2: server.URL, CountryCode: countryCode}
+
+	albums, err := client.GetArtistAlbumsV2(context.Background(), "11950", V2Params{})
+	if err != nil {
+		t.Fatalf("Client.GetArtistAlbumsV2() error = %v", err)
+	}
+
+	var titles []string
+	for _, album := range albums {
+		titles = append(titles, album.Title)
+	}
+
+	// The last album is not included in the document, so only its ID is known.
+	want := []string{"Power Corruption and Lies", "Low-Life", "Brotherhood", ""}
+	if !reflect.DeepEqual(titles, want) {
+		t.Errorf("Client.GetArtistAlbumsV2() = %v, want %v", titles, want)
+	}
+
+	if last := albums[len(albums)-1]; last.ID != "17927865" {
+		t.Errorf("Client.GetArtistAlbumsV2() ID = %q, want 17927865", last.ID)
+	}
+}
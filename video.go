@@ -1,5 +1,12 @@
 package gotidal
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
 // Video represents an individula video.
 type Video struct {
 	videoResource `json:"resource"`
@@ -7,6 +14,7 @@ type Video struct {
 
 type videoResource struct {
 	ID           string           `json:"id"`
+	ArtifactType string           `json:"artifactType"`
 	Title        string           `json:"title"`
 	Version      string           `json:"version"`
 	Images       []Image          `json:"image"`
@@ -27,3 +35,24 @@ type VideoProperties struct {
 	Content   []string `json:"content"`
 	VideoType string   `json:"video-type"`
 }
+
+// GetSingleVideo returns a video that matches an ID.
+func (c *Client) GetSingleVideo(ctx context.Context, id string) (*Video, error) {
+	if id == "" {
+		return nil, ErrMissingRequiredParameters
+	}
+
+	response, err := c.request(ctx, http.MethodGet, concat("/videos/", id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the videos endpoint: %w", err)
+	}
+
+	var result Video
+
+	err = json.Unmarshal(response, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the videos response body: %w", err)
+	}
+
+	return &result, nil
+}